@@ -0,0 +1,190 @@
+package dimacs
+
+import (
+	"Deriver/fmla"
+	"Deriver/sem"
+	"strings"
+	"testing"
+)
+
+// satisfiable decides the clauses by brute force, for a check against truth tables.
+func satisfiable(cs *fmla.ClauseSet) (sat bool) {
+	var (
+		n   uint
+		cl  fmla.Clause
+		lit fmla.Literal
+		ok  bool
+	)
+
+	for n = range uint(1) << cs.NumVars {
+		sat = true
+
+		for _, cl = range cs.Clauses {
+			ok = false
+
+			for _, lit = range cl {
+				ok = ok || (n&(1<<(lit.Var()-1)) != 0) == (0 < lit)
+			}
+
+			if sat = sat && ok; !sat {
+				break
+			}
+		}
+
+		if sat {
+			return
+		}
+	}
+
+	return
+}
+
+func TestTseitin(t *testing.T) {
+	var (
+		ss  []string
+		s   string
+		wff *fmla.WffTree
+		tt  *sem.TruthTable
+		err error
+	)
+
+	ss = []string{
+		"(P→Q)∧¬(Q∨R)",
+		"(P↔Q)∧(P↔¬Q)",
+		"¬((P∧Q)→P)",
+		"(P∨⊥)∧¬(P∧⊤)",
+		"(P∨Q)∧(¬P∨R)",
+	}
+
+	for _, s = range ss {
+		if wff, err = fmla.ParseWff(s); err != nil {
+			t.Fatalf("\nFAILED: %v", err)
+		}
+
+		if tt, err = sem.NewTruthTable(wff); err != nil {
+			t.Fatalf("\nFAILED: %v", err)
+		}
+
+		if satisfiable(fmla.Tseitin(wff)) != (tt.Status != sem.Contradiction) {
+			t.Errorf("\nFAILED: Expected the clauses of %q to be satisfiable just when it is.", s)
+		} else {
+			t.Logf("\nPASSED: The clauses of %q agree with its truth table.", s)
+		}
+	}
+}
+
+func TestWriteAndReadModel(t *testing.T) {
+	var (
+		wff      *fmla.WffTree
+		cs       *fmla.ClauseSet
+		sb       strings.Builder
+		mdl      Model
+		val      sem.Valuation
+		atomP    *fmla.WffTree
+		sat      bool
+		expected string
+		am       *AtomMap
+		err      error
+	)
+
+	wff, _ = fmla.ParseWff("(P→Q)∧¬Q")
+
+	cs = fmla.Tseitin(wff)
+
+	if err = Write(&sb, cs); err != nil {
+		t.Fatalf("\nFAILED: %v", err)
+	}
+
+	expected = strings.Join([]string{
+		"c v 1 P",
+		"c v 2 Q",
+		"p cnf 4 7",
+		"-3 -1 2 0",
+		"3 1 0",
+		"3 -2 0",
+		"-4 3 0",
+		"-4 -2 0",
+		"4 -3 2 0",
+		"4 0",
+		"",
+	}, "\n")
+
+	if sb.String() != expected {
+		t.Errorf("\nFAILED: Expected\n%s\ngot\n%s", expected, sb.String())
+	}
+
+	if mdl, sat, err = ReadModel(strings.NewReader("c solved\ns SATISFIABLE\nv -1 -2 3\nv 4 0\n"), cs); err != nil || !sat {
+		t.Fatalf("\nFAILED: %v", err)
+	}
+
+	if val, err = ValuationOf(mdl); err != nil || val['P'] || val['Q'] {
+		t.Errorf("\nFAILED: Expected P and Q to be false, got %v (%v).", val, err)
+	} else {
+		t.Logf("\nPASSED: Read the model %v.", val)
+	}
+
+	if _, sat, err = ReadModel(strings.NewReader("UNSAT\n"), cs); err != nil || sat {
+		t.Errorf("\nFAILED: Expected an unsatisfiable answer, got %t (%v).", sat, err)
+	}
+
+	if _, _, err = ReadModel(strings.NewReader("s SATISFIABLE\nv 9 0\n"), cs); err == nil {
+		t.Errorf("\nFAILED: Expected an error for a variable out of range.")
+	}
+
+	// A later process has only the file Write wrote, not the clauses.
+	if am, err = ReadAtomMap(strings.NewReader(sb.String())); err != nil {
+		t.Fatalf("\nFAILED: %v", err)
+	}
+
+	if am.NumVars != 4 || len(am.Atoms) != 2 || fmla.GetWffString(am.Atoms[2]) != "Q" {
+		t.Errorf("\nFAILED: Expected P and Q for variables 1 and 2 of 4, got %v.", am)
+	}
+
+	atomP, _ = fmla.ParseWff("P")
+
+	if mdl, sat, err = ReadModelWith(strings.NewReader("SAT\n1 -2 -3 4 0\n"), am); err != nil || !sat || !mdl[atomP] {
+		t.Errorf("\nFAILED: Expected P true, got %t (%v).", mdl[atomP], err)
+	}
+
+	if _, err = ReadAtomMap(strings.NewReader("c v 1 P∧Q\np cnf 1 0\n")); err == nil {
+		t.Errorf("\nFAILED: Expected an error for a compound atom.")
+	}
+}
+
+func TestReadModelOfGroundAtoms(t *testing.T) {
+	var (
+		wff, atomF, atomE *fmla.WffTree
+		sb                strings.Builder
+		am                *AtomMap
+		mdl               Model
+		sat               bool
+		err               error
+	)
+
+	wff, _ = fmla.ParseWff("Fa∧¬a=b")
+
+	if err = Write(&sb, fmla.Tseitin(wff)); err != nil {
+		t.Fatalf("\nFAILED: %v", err)
+	}
+
+	if am, err = ReadAtomMap(strings.NewReader(sb.String())); err != nil {
+		t.Fatalf("\nFAILED: %v", err)
+	}
+
+	if mdl, sat, err = ReadModelWith(strings.NewReader("SAT\n1 -2 3 0\n"), am); err != nil || !sat {
+		t.Fatalf("\nFAILED: %v", err)
+	}
+
+	atomF, _ = fmla.ParseWff("Fa")
+	atomE, _ = fmla.ParseWff("a=b")
+
+	if len(mdl) != 2 || !mdl[atomF] || mdl[atomE] {
+		t.Errorf("\nFAILED: Expected Fa true and a=b false of 2 atoms, got %t and %t of %d.", mdl[atomF], mdl[atomE], len(mdl))
+	} else {
+		t.Logf("\nPASSED: Read Fa as true and a=b as false.")
+	}
+
+	if _, err = ValuationOf(mdl); err == nil || !strings.Contains(err.Error(), "sem.Valuation") {
+		t.Errorf("\nFAILED: Expected an error naming sem.Valuation for Fa, got %v.", err)
+	}
+}
@@ -0,0 +1,252 @@
+package dimacs
+
+import (
+	"Deriver/fmla"
+	"Deriver/sem"
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+type ReadError struct {
+	Line int    // The line of the input, from 1, at which reading failed.
+	Msg  string // What was wrong there.
+}
+
+func (rerr *ReadError) Error() (s string) {
+	s = fmt.Sprintf("dimacs: %s on line %d", rerr.Msg, rerr.Line)
+
+	return
+}
+
+// An AtomMap gives the atomic formula of each variable that has one, numbered from 1,
+// as the "c v" comments that Write puts ahead of the header do.
+type AtomMap struct {
+	NumVars int
+	Atoms   map[int]*fmla.WffTree
+}
+
+// AtomMapOf returns the AtomMap of clauses still in memory.
+func AtomMapOf(cs *fmla.ClauseSet) (am *AtomMap) {
+	var (
+		dex  int
+		atom *fmla.WffTree
+	)
+
+	am = &AtomMap{NumVars: cs.NumVars, Atoms: map[int]*fmla.WffTree{}}
+
+	for dex, atom = range cs.Atoms {
+		if atom != nil {
+			am.Atoms[dex+1] = atom
+		}
+	}
+
+	return
+}
+
+// ReadAtomMap reads back the AtomMap from a CNF file that Write wrote,
+// so that a model found in another process can still be mapped to atoms.
+// It reads the "c v" comments and the header, and stops there.
+func ReadAtomMap(r io.Reader) (am *AtomMap, err error) {
+	var (
+		sc       *bufio.Scanner
+		line     int
+		fields   []string
+		n, nCls  int
+		atom     *fmla.WffTree
+		errParse error
+	)
+
+	sc, am = bufio.NewScanner(r), &AtomMap{Atoms: map[int]*fmla.WffTree{}}
+
+	for sc.Scan() {
+		line += 1
+
+		if fields = strings.Fields(sc.Text()); len(fields) == 0 {
+			continue
+		}
+
+		switch {
+		case fields[0] == "c" && 1 < len(fields) && fields[1] == "v":
+			if len(fields) != 4 {
+				err = &ReadError{line, fmt.Sprintf("expected a variable and an atom, not %q", sc.Text())}
+
+				return
+			}
+
+			if n, errParse = strconv.Atoi(fields[2]); errParse != nil || n < 1 {
+				err = &ReadError{line, fmt.Sprintf("%q is not a variable", fields[2])}
+
+				return
+			}
+
+			if atom, errParse = fmla.ParseWff(fields[3]); errParse != nil || fmla.GetWffKind(atom) != fmla.Atomic {
+				err = &ReadError{line, fmt.Sprintf("%q is not an atomic formula", fields[3])}
+
+				return
+			}
+
+			am.Atoms[n] = atom
+		case fields[0] == "c":
+		case fields[0] == "p":
+			if _, errParse = fmt.Sscanf(sc.Text(), "p cnf %d %d", &am.NumVars, &nCls); errParse != nil {
+				err = &ReadError{line, fmt.Sprintf("expected a header, not %q", sc.Text())}
+
+				return
+			}
+
+			for n = range am.Atoms {
+				if am.NumVars < n {
+					err = &ReadError{line, fmt.Sprintf("variable %d is past the %d of the header", n, am.NumVars)}
+
+					return
+				}
+			}
+
+			return
+		default:
+			err = &ReadError{line, fmt.Sprintf("expected a comment or header, not %q", sc.Text())}
+
+			return
+		}
+	}
+
+	if err = sc.Err(); err == nil {
+		err = &ReadError{line, "missing the header"}
+	}
+
+	return
+}
+
+// A Model gives the value of each atom of the clauses in a SAT solver's model,
+// which may be a 0-place predicate constant or a ground atom such as Fa or a=b.
+// Equal atoms share one interned node, so an atom parsed or built anew finds its value.
+type Model map[*fmla.WffTree]bool
+
+// ReadModel reads a SAT solver's answer for the clauses, in either the competition format,
+// with an "s" status line and "v" value lines, or MiniSat's, with a bare status line and literals.
+// It reports whether the clauses were satisfiable and, if so, the value of each atom in the model.
+// Atoms that the model leaves out are false.
+func ReadModel(r io.Reader, cs *fmla.ClauseSet) (mdl Model, sat bool, err error) {
+	mdl, sat, err = ReadModelWith(r, AtomMapOf(cs))
+
+	return
+}
+
+// ReadModelWith is ReadModel for clauses known only by their AtomMap, as read by ReadAtomMap.
+func ReadModelWith(r io.Reader, am *AtomMap) (mdl Model, sat bool, err error) {
+	var (
+		sc       *bufio.Scanner
+		line     int
+		fields   []string
+		field    string
+		n        int
+		status   bool
+		values   map[int]bool
+		dex      int
+		atom     *fmla.WffTree
+		errParse error
+	)
+
+	sc, values = bufio.NewScanner(r), map[int]bool{}
+
+	for sc.Scan() {
+		line += 1
+
+		if fields = strings.Fields(sc.Text()); len(fields) == 0 {
+			continue
+		}
+
+		switch fields[0] {
+		case "c":
+			continue
+		case "s":
+			fields = fields[1:]
+		case "v":
+			fields = fields[1:]
+
+			if !status {
+				err = &ReadError{line, "values come before the status"}
+
+				return
+			}
+		}
+
+		switch strings.Join(fields, " ") {
+		case "SATISFIABLE", "SAT":
+			status, sat = true, true
+
+			continue
+		case "UNSATISFIABLE", "UNSAT":
+			status = true
+
+			return
+		}
+
+		if !status {
+			err = &ReadError{line, fmt.Sprintf("expected a status, not %q", sc.Text())}
+
+			return
+		}
+
+		for _, field = range fields {
+			if n, errParse = strconv.Atoi(field); errParse != nil || am.NumVars < max(n, -n) {
+				err = &ReadError{line, fmt.Sprintf("%q is not a literal", field)}
+
+				return
+			}
+
+			if n != 0 {
+				values[max(n, -n)] = 0 < n
+			}
+		}
+	}
+
+	if err = sc.Err(); err != nil {
+		return
+	}
+
+	if !status {
+		err = &ReadError{line, "missing the status"}
+
+		return
+	}
+
+	mdl = Model{}
+
+	for dex, atom = range am.Atoms {
+		mdl[atom] = values[dex]
+	}
+
+	return
+}
+
+// ValuationOf returns a propositional model as a sem.Valuation.
+// It fails if any atom isn't a 0-place predicate constant, the only atoms a sem.Valuation can value.
+func ValuationOf(mdl Model) (val sem.Valuation, err error) {
+	var (
+		atom  *fmla.WffTree
+		value bool
+		pred  fmla.Predicate
+		args  []fmla.Argument
+	)
+
+	val = sem.Valuation{}
+
+	for atom, value = range mdl {
+		if pred, args, _ = fmla.GetWffPredAndArgs(atom); len(args) != 0 || !fmla.IsPredConst(pred) {
+			err = fmt.Errorf("dimacs: %s is not a 0-place predicate constant, the only atoms a sem.Valuation can value",
+				fmla.GetWffString(atom))
+
+			val = nil
+
+			return
+		}
+
+		val[pred] = value
+	}
+
+	return
+}
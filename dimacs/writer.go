@@ -0,0 +1,43 @@
+package dimacs
+
+import (
+	"Deriver/fmla"
+	"bufio"
+	"fmt"
+	"io"
+)
+
+// Write writes the clauses in DIMACS CNF. Comment lines of the form "c v 3 Fa"
+// ahead of the header map each variable back to its atomic formula;
+// the variables that Tseitin added go unmapped.
+func Write(w io.Writer, cs *fmla.ClauseSet) (err error) {
+	var (
+		bw   *bufio.Writer
+		dex  int
+		atom *fmla.WffTree
+		cl   fmla.Clause
+		lit  fmla.Literal
+	)
+
+	bw = bufio.NewWriter(w)
+
+	for dex, atom = range cs.Atoms {
+		if atom != nil {
+			fmt.Fprintf(bw, "c v %d %s\n", dex+1, fmla.GetWffString(atom))
+		}
+	}
+
+	fmt.Fprintf(bw, "p cnf %d %d\n", cs.NumVars, len(cs.Clauses))
+
+	for _, cl = range cs.Clauses {
+		for _, lit = range cl {
+			fmt.Fprintf(bw, "%d ", lit)
+		}
+
+		fmt.Fprintln(bw, "0")
+	}
+
+	err = bw.Flush()
+
+	return
+}
@@ -1,5 +1,9 @@
 package fmla
 
+import (
+	"context"
+)
+
 func NewAtomicWff(pc Predicate, acs ...Argument) (wff *WffTree) {
 	var (
 		lenA int
@@ -9,14 +13,21 @@ func NewAtomicWff(pc Predicate, acs ...Argument) (wff *WffTree) {
 		panic("Equals predicate requires exactly two arguments.")
 	}
 
-	wff = &WffTree{
-		kind: Atomic,
-		mop:  NoSymbol,
-		pred: pc,
-		args: argsToArgString(acs...),
+	wff = newAtomic(pc, argsToArgString(acs...))
+
+	return
+}
+
+func NewAtomicTermWff(pc Predicate, ts ...*Term) (wff *WffTree) {
+	var (
+		lenT int
+	)
+
+	if lenT = len(ts); pc == Equals && lenT != 2 {
+		panic("Equals predicate requires exactly two arguments.")
 	}
 
-	wff.h = hashWff(wff)
+	wff = newAtomic(pc, termsToArgString(ts...))
 
 	return
 }
@@ -33,7 +44,7 @@ func buildTupsAtArity(domA uint, arity uint) (tups [][]Argument, lenT uint) {
 		tups = [][]Argument{}
 	case arity == 1:
 		for dexA < domA {
-			tups = append(tups, []Argument{ArgConsts[dexA]})
+			tups = append(tups, []Argument{NthArgConst(dexA)})
 
 			dexA += 1
 		}
@@ -58,103 +69,18 @@ func buildTupsAtArity(domA uint, arity uint) (tups [][]Argument, lenT uint) {
 	return
 }
 
+// BuildAtomicWffs sends the formulae of AtomicWffs on a channel,
+// which must be drained; otherwise, range over AtomicWffs or use BuildAtomicWffsContext instead.
 func BuildAtomicWffs(domP uint, domA uint, arity uint) (wffs chan *WffTree) {
-	domP, domA = min(domP, 20), min(domA, 20)
-
-	wffs = make(chan *WffTree)
-
-	switch domP {
-	case 0:
-		go func() {
-			wffs <- NewAtomicWff(Top)
-
-			wffs <- NewAtomicWff(Bot)
-
-			close(wffs)
-		}()
-	default:
-		go func(dP, dA, ity uint) {
-			var (
-				pcs  []Predicate
-				pc   Predicate
-				tups [][]Argument
-				lenT uint
-				tup  []Argument
-			)
-
-			pcs = append([]Predicate{}, PredConsts[:dP]...)
-
-			tups, lenT = buildTupsAtArity(dA, ity)
-
-			switch lenT {
-			case 0:
-				for _, pc = range pcs {
-					wffs <- NewAtomicWff(pc)
-				}
-			default:
-				for _, pc = range pcs {
-					for _, tup = range tups {
-						wffs <- NewAtomicWff(pc, tup...)
-					}
-				}
-			}
-
-			if ity == 2 {
-				for _, tup = range tups {
-					wffs <- NewAtomicWff(Equals, tup...)
-				}
-			}
-
-			close(wffs)
-		}(domP, domA, arity)
-	}
+	wffs = seqToChan(context.Background(), AtomicWffs(domP, domA, arity))
 
 	return
 }
 
+// BuildMixedAtomicWffs sends the formulae of MixedAtomicWffs on a channel,
+// which must be drained; otherwise, range over MixedAtomicWffs or use BuildMixedAtomicWffsContext instead.
 func BuildMixedAtomicWffs(maxDomP uint, maxDomA uint, maxArity uint) (wffs chan *WffTree) {
-
-	wffs = make(chan *WffTree)
-
-	go func(mdP, mdA, mIty uint) {
-		var (
-			ity   uint
-			pwffs chan *WffTree
-			pwff  *WffTree
-		)
-
-		// Get Top and Bot.
-		pwffs = BuildAtomicWffs(0, 0, 0)
-
-		for pwff = range pwffs {
-			wffs <- pwff
-		}
-
-		// Get 0-place predicates.
-		if 0 < mdP {
-			pwffs = BuildAtomicWffs(mdP, 0, 0)
-
-			for pwff = range pwffs {
-				wffs <- pwff
-			}
-
-			if 0 < mdA {
-				for ity = 0; !(mIty < ity); ity += 1 {
-					if ity*mdA == 0 {
-						continue
-					}
-
-					pwffs = BuildAtomicWffs(mdP, mdA, ity)
-
-					for pwff = range pwffs {
-						wffs <- pwff
-					}
-				}
-			}
-		}
-
-		close(wffs)
-	}(maxDomP, maxDomA, maxArity)
+	wffs = seqToChan(context.Background(), MixedAtomicWffs(maxDomP, maxDomA, maxArity))
 
 	return
 }
@@ -1,6 +1,10 @@
 package fmla
 
-import "slices"
+import (
+	"context"
+	"maps"
+	"slices"
+)
 
 func orderAtomics(wff *WffTree) (atoms []*WffTree) {
 	var (
@@ -43,7 +47,7 @@ ISCANONICAL_OUTER:
 			continue
 		}
 
-		if slices.Contains(PredConsts, atom.pred) && PredConsts[pcDex] < atom.pred {
+		if IsPredConst(atom.pred) && pcDex < PredOrdinal(atom.pred) {
 			is = false
 
 			break
@@ -52,7 +56,7 @@ ISCANONICAL_OUTER:
 		}
 
 		for _, ac = range argStringToArgs(atom.args) {
-			if slices.Contains(ArgConsts, ac) && ArgConsts[acDex] < ac {
+			if IsArgConst(ac) && acDex < ArgOrdinal(ac) {
 				is = false
 
 				break ISCANONICAL_OUTER
@@ -65,12 +69,58 @@ ISCANONICAL_OUTER:
 	return
 }
 
+// renameSymbols renames the predicates, arguments and bound variables of a formula by the maps,
+// leaving those the maps don't mention as they are.
+func renameSymbols(wff *WffTree, pMap map[Predicate]Predicate, aMap map[Argument]Argument) (wffR *WffTree) {
+	var (
+		pred, pVar Predicate
+		aVar       Argument
+		args       []Argument
+		dex        int
+		ok         bool
+	)
+
+	switch wff.kind {
+	case Quantified:
+		if pVar, ok = pMap[wff.pVar]; !ok {
+			pVar = wff.pVar
+		}
+
+		if aVar, ok = aMap[wff.aVar]; !ok {
+			aVar = wff.aVar
+		}
+
+		wffR = intern(nodeKey{kind: Quantified, mop: wff.mop, pVar: pVar, aVar: aVar, subL: renameSymbols(wff.subL, pMap, aMap)})
+	case Atomic:
+		if pred, ok = pMap[wff.pred]; !ok {
+			pred = wff.pred
+		}
+
+		args = argStringToArgs(wff.args)
+
+		for dex = range args {
+			if aVar, ok = aMap[args[dex]]; ok {
+				args[dex] = aVar
+			}
+		}
+
+		wffR = newAtomic(pred, argsToArgString(args...))
+	case Unary:
+		wffR = rebuild(wff, renameSymbols(wff.subL, pMap, aMap), nil)
+	case Binary:
+		wffR = rebuild(wff, renameSymbols(wff.subL, pMap, aMap), renameSymbols(wff.subR, pMap, aMap))
+	default:
+		panic("Invalid WffTree")
+	}
+
+	return
+}
+
 func MakeCanonical(wff *WffTree) (cwff *WffTree) {
 	var (
 		pcMap, pvMap               map[Predicate]Predicate
 		acMap, avMap               map[Argument]Argument
-		pcDex, pvDex, acDex, avIdx int
-		lenPC, lenPV, lenAC, lenAV int
+		pcDex, pvDex, acDex, avIdx uint
 	)
 
 	pcMap = map[Predicate]Predicate{}
@@ -79,8 +129,6 @@ func MakeCanonical(wff *WffTree) (cwff *WffTree) {
 	acMap = map[Argument]Argument{}
 	avMap = map[Argument]Argument{}
 
-	lenPC, lenPV, lenAC, lenAV = len(PredConsts), len(PredVars), len(ArgConsts), len(ArgVars)
-
 	// Pass 1: Traverse the tree to build the replacement mappings
 	var buildMaps func(wt *WffTree)
 
@@ -98,17 +146,17 @@ func MakeCanonical(wff *WffTree) (cwff *WffTree) {
 		switch wt.kind {
 		case Atomic:
 			// Only map predicase constants and variables, not Top, Bot, or Equals.
-			if slices.Contains(PredConsts, wt.pred) {
-				if _, ok = pcMap[wt.pred]; !ok && pcDex < lenPC {
-					pcMap[wt.pred] = PredConsts[pcDex]
+			if IsPredConst(wt.pred) {
+				if _, ok = pcMap[wt.pred]; !ok {
+					pcMap[wt.pred] = NthPredConst(pcDex)
 
 					pcDex += 1
 				}
 			}
 
-			if slices.Contains(PredVars, wt.pred) {
-				if _, ok = pvMap[wt.pred]; !ok && pvDex < lenPV {
-					pvMap[wt.pred] = PredVars[pvDex]
+			if IsPredVar(wt.pred) {
+				if _, ok = pvMap[wt.pred]; !ok {
+					pvMap[wt.pred] = NthPredVar(pvDex)
 
 					pvDex += 1
 				}
@@ -119,15 +167,15 @@ func MakeCanonical(wff *WffTree) (cwff *WffTree) {
 
 			for _, arg = range args {
 				switch {
-				case slices.Contains(ArgConsts, arg):
-					if _, ok = acMap[arg]; !ok && acDex < lenAC {
-						acMap[arg] = ArgConsts[acDex]
+				case IsArgConst(arg):
+					if _, ok = acMap[arg]; !ok {
+						acMap[arg] = NthArgConst(acDex)
 
 						acDex += 1
 					}
-				case slices.Contains(ArgVars, arg):
-					if _, ok = avMap[arg]; !ok && avIdx < len(ArgVars) {
-						avMap[arg] = ArgVars[avIdx]
+				case IsArgVar(arg):
+					if _, ok = avMap[arg]; !ok {
+						avMap[arg] = NthArgVar(avIdx)
 
 						avIdx += 1
 					}
@@ -140,16 +188,16 @@ func MakeCanonical(wff *WffTree) (cwff *WffTree) {
 			buildMaps(wt.subR)
 		case Quantified:
 			if wt.pVar != 0 {
-				if _, ok = pvMap[wt.pVar]; !ok && pvDex < lenPV {
-					pvMap[wt.pVar] = PredVars[pvDex]
+				if _, ok = pvMap[wt.pVar]; !ok {
+					pvMap[wt.pVar] = NthPredVar(pvDex)
 
 					pvDex += 1
 				}
 			}
 
 			if wt.aVar != 0 {
-				if _, ok = avMap[wt.aVar]; !ok && avIdx < lenAV {
-					avMap[wt.aVar] = ArgVars[avIdx]
+				if _, ok = avMap[wt.aVar]; !ok {
+					avMap[wt.aVar] = NthArgVar(avIdx)
 
 					avIdx += 1
 				}
@@ -163,83 +211,282 @@ func MakeCanonical(wff *WffTree) (cwff *WffTree) {
 
 	buildMaps(wff)
 
-	cwff = DeepCopy(wff)
+	maps.Copy(pcMap, pvMap)
+	maps.Copy(acMap, avMap)
 
-	var applyMaps func(wt *WffTree)
+	cwff = renameSymbols(wff, pcMap, acMap)
 
-	applyMaps = func(wt *WffTree) {
-		var (
-			mpc, mpv Predicate
-			mac, mav Argument
-			ok       bool
-			args     []Argument
-			arg      Argument
-			dex      int
-		)
+	return
+}
 
-		if wt == nil {
-			panic("Invalid WffTree")
+// KeepCanonicalWffs sends the canonical formulae received on wffs on a channel, which must be drained;
+// otherwise, range over CanonicalWffs or use KeepCanonicalWffsContext instead.
+func KeepCanonicalWffs(wffs chan *WffTree) (cwffs chan *WffTree) {
+	cwffs = seqToChan(context.Background(), CanonicalWffs(chanToSeq(wffs)))
+
+	return
+}
+
+// flattenOperands lists the operands of a chain of one associative operator, left to right.
+func flattenOperands(wff *WffTree, mop Symbol) (ops []*WffTree) {
+	if wff.kind == Binary && wff.mop == mop {
+		ops = append(flattenOperands(wff.subL, mop), flattenOperands(wff.subR, mop)...)
+	} else {
+		ops = []*WffTree{wff}
+	}
+
+	return
+}
+
+// sortOperands regroups every chain of ∧, ∨ or ↔ to the left, with its operands in order.
+// Operands are ordered first by their shape, which renaming leaves alone, and then by Compare.
+func sortOperands(wff *WffTree) (wffS *WffTree) {
+	var (
+		ops    []*WffTree
+		op     *WffTree
+		dex    int
+		shapes map[*WffTree]*WffTree
+	)
+
+	switch wff.kind {
+	case Atomic:
+		wffS = wff
+	case Unary, Quantified:
+		wffS = rebuild(wff, sortOperands(wff.subL), nil)
+	case Binary:
+		if wff.mop == To {
+			wffS = rebuild(wff, sortOperands(wff.subL), sortOperands(wff.subR))
+
+			break
 		}
 
-		switch wt.kind {
-		case Quantified:
-			if wt.pVar != 0 {
-				wt.pVar = pvMap[wt.pVar]
-			}
-			if wt.aVar != 0 {
-				wt.aVar = avMap[wt.aVar]
+		shapes = map[*WffTree]*WffTree{}
+
+		for _, op = range flattenOperands(wff, wff.mop) {
+			op = sortOperands(op)
+
+			shapes[op], ops = MakeCanonical(op), append(ops, op)
+		}
+
+		slices.SortStableFunc(ops, func(opA, opB *WffTree) (comp int) {
+			if comp = Compare(shapes[opA], shapes[opB]); comp == 0 {
+				comp = Compare(opA, opB)
 			}
 
-			applyMaps(wt.subL)
+			return
+		})
+
+		for wffS, dex = ops[0], 1; dex < len(ops); dex += 1 {
+			wffS = NewCompositeWff(wff.mop, wffS, ops[dex], 0, 0)
+		}
+	default:
+		panic("Invalid WffTree")
+	}
+
+	return
+}
+
+// MaxACRenamings bounds the renamings MakeACCanonical tries before it falls back on sorting and renaming in turn.
+const MaxACRenamings = 5040
+
+// getSymbolGroups returns the predicate constants, predicate variables, argument constants
+// and argument variables of a formula, bound ones included, each group in order.
+func getSymbolGroups(wff *WffTree) (groups [4][]rune) {
+	var (
+		atom *WffTree
+		arg  Argument
+		dex  int
+		add  func(dex int, r rune)
+		walk func(sub *WffTree)
+	)
+
+	add = func(dex int, r rune) {
+		if !slices.Contains(groups[dex], r) {
+			groups[dex] = append(groups[dex], r)
+		}
+	}
+
+	walk = func(sub *WffTree) {
+		switch sub.kind {
 		case Atomic:
-			if mpc, ok = pcMap[wt.pred]; ok {
-				wt.pred = mpc
-			} else if mpv, ok = pvMap[wt.pred]; ok {
-				wt.pred = mpv
+		case Quantified:
+			switch {
+			case IsPredConst(sub.pVar):
+				add(0, rune(sub.pVar))
+			case IsPredVar(sub.pVar):
+				add(1, rune(sub.pVar))
+			case IsArgConst(sub.aVar):
+				add(2, rune(sub.aVar))
+			case IsArgVar(sub.aVar):
+				add(3, rune(sub.aVar))
 			}
 
-			args = argStringToArgs(wt.args)
+			walk(sub.subL)
+		default:
+			walk(sub.subL)
 
-			for dex, arg = range args {
-				if mac, ok = acMap[arg]; ok {
-					args[dex] = mac
-				} else if mav, ok = avMap[arg]; ok {
-					args[dex] = mav
-				}
+			if sub.subR != nil {
+				walk(sub.subR)
 			}
+		}
+	}
 
-			wt.args = argsToArgString(args...)
-		case Unary:
-			applyMaps(wt.subL)
-		case Binary:
-			applyMaps(wt.subL)
-			applyMaps(wt.subR)
+	walk(wff)
+
+	for _, atom = range orderAtomics(wff) {
+		switch {
+		case IsPredConst(atom.pred):
+			add(0, rune(atom.pred))
+		case IsPredVar(atom.pred):
+			add(1, rune(atom.pred))
+		}
+
+		for _, arg = range argStringToArgs(atom.args) {
+			switch {
+			case IsArgConst(arg):
+				add(2, rune(arg))
+			case IsArgVar(arg):
+				add(3, rune(arg))
+			}
 		}
 	}
 
-	applyMaps(cwff)
+	for dex = range groups {
+		slices.Sort(groups[dex])
+	}
 
-	cwff.h = hashWff(cwff)
+	return
+}
+
+// permutations returns every ordering of the elements.
+func permutations[T any](ts []T) (perms [][]T) {
+	var (
+		dex  int
+		perm []T
+	)
+
+	if len(ts) < 2 {
+		perms = [][]T{slices.Clone(ts)}
+
+		return
+	}
+
+	for dex = range ts {
+		for _, perm = range permutations(slices.Concat(ts[:dex], ts[dex+1:])) {
+			perms = append(perms, append([]T{ts[dex]}, perm...))
+		}
+	}
 
 	return
 }
 
-func KeepCanonicalWffs(wffs chan *WffTree) (cwffs chan *WffTree) {
-	cwffs = make(chan *WffTree)
+// MakeACCanonical returns a representative of the formulae that differ from wff only
+// by the grouping and order of the operands of ∧, ∨ and ↔ and by a renaming, as MakeCanonical does.
+// It is the least, by Compare, of the sorted forms of every renaming of wff onto the first symbols of each kind,
+// so formulae alike up to AC and renaming get the same one. Past MaxACRenamings renamings,
+// the operands are instead sorted and the symbols renamed by order of appearance until neither changes anything,
+// which gives a representative, but not always the same one for formulae that are alike.
+// Regrouping ↔ is sound classically but not intuitionistically.
+func MakeACCanonical(wff *WffTree) (cwff *WffTree) {
+	var (
+		groups  [4][]rune
+		perms   [4][][]rune
+		targets []rune
+		count   int
+		digits  []int
+		dex, n  int
+		pMap    map[Predicate]Predicate
+		aMap    map[Argument]Argument
+		r       rune
+		wffP    *WffTree
+		wffR    *WffTree
+	)
 
-	go func() {
-		var (
-			wff *WffTree
-		)
+	if wff == nil {
+		panic("Invalid WffTree")
+	}
+
+	groups, count = getSymbolGroups(DeepCopy(wff)), 1
+
+	for dex = range groups {
+		for n = 2; n < len(groups[dex])+1 && count < MaxACRenamings+1; n += 1 {
+			count *= n
+		}
+	}
+
+	if MaxACRenamings < count {
+		for cwff = MakeCanonical(sortOperands(DeepCopy(wff))); cwff != wffP; cwff = MakeCanonical(sortOperands(cwff)) {
+			wffP = cwff
+		}
+
+		return
+	}
+
+	for dex = range groups {
+		targets = []rune{}
+
+		for n = range groups[dex] {
+			switch dex {
+			case 0:
+				targets = append(targets, rune(NthPredConst(uint(n))))
+			case 1:
+				targets = append(targets, rune(NthPredVar(uint(n))))
+			case 2:
+				targets = append(targets, rune(NthArgConst(uint(n))))
+			case 3:
+				targets = append(targets, rune(NthArgVar(uint(n))))
+			}
+		}
+
+		perms[dex] = permutations(targets)
+	}
+
+	// Each choice of one permutation per group is a renaming, counted off like the digits of an odometer.
+	for digits = make([]int, 4); ; {
+		pMap, aMap = map[Predicate]Predicate{}, map[Argument]Argument{}
+
+		for dex = range groups {
+			for n, r = range groups[dex] {
+				if dex < 2 {
+					pMap[Predicate(r)] = Predicate(perms[dex][digits[dex]][n])
+				} else {
+					aMap[Argument(r)] = Argument(perms[dex][digits[dex]][n])
+				}
+			}
+		}
+
+		if wffR = sortOperands(renameSymbols(wff, pMap, aMap)); cwff == nil || Compare(wffR, cwff) < 0 {
+			cwff = wffR
+		}
 
-		for wff = range wffs {
-			if IsCanonical(wff) {
-				cwffs <- wff
+		for dex = 0; dex < 4; dex += 1 {
+			if digits[dex] += 1; digits[dex] < len(perms[dex]) {
+				break
 			}
+
+			digits[dex] = 0
+		}
+
+		if dex == 4 {
+			break
 		}
+	}
+
+	return
+}
+
+// IsACCanonical reports whether a formula is its own MakeACCanonical, so that among the formulae
+// that are the same up to AC and renaming, just one passes.
+func IsACCanonical(wff *WffTree) (is bool) {
+	is = MakeACCanonical(wff) == DeepCopy(wff)
+
+	return
+}
 
-		close(cwffs)
-	}()
+// KeepACCanonicalWffs sends the AC-canonical formulae received on wffs on a channel, which must be drained;
+// otherwise, range over ACCanonicalWffs instead.
+func KeepACCanonicalWffs(wffs chan *WffTree) (cwffs chan *WffTree) {
+	cwffs = seqToChan(context.Background(), ACCanonicalWffs(chanToSeq(wffs)))
 
 	return
 }
@@ -0,0 +1,85 @@
+package fmla
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestMakeACCanonical(t *testing.T) {
+	var (
+		groups    [][]string
+		group     []string
+		s         string
+		wff, cwff *WffTree
+		cwffG     *WffTree
+		cwffs     []*WffTree
+		err       error
+	)
+
+	// The formulae of each group are alike up to AC and renaming, and unlike those of the other groups.
+	groups = [][]string{
+		{"P∧Q", "Q∧P", "R∧P"},
+		{"(P∧Q)∧R", "P∧(Q∧R)", "(R∧P)∧Q"},
+		{"(P∧Q)∨(P∧⊤)", "(Q∧P)∨(Q∧⊤)", "(⊤∧Q)∨(P∧Q)"},
+		{"∀x(Fx∨Ga)↔P", "P↔∀y(Hb∨Fy)"},
+		{"P∧P"},
+		{"P→Q", "R→P"},
+		{"Q→(P∧P)"},
+	}
+
+	for _, group = range groups {
+		cwffG = nil
+
+		for _, s = range group {
+			if wff, err = ParseWff(s); err != nil {
+				t.Fatalf("\nFAILED: %v", err)
+			}
+
+			cwff = MakeACCanonical(wff)
+
+			switch {
+			case cwffG != nil && cwff != cwffG:
+				t.Errorf("\nFAILED: Expected %q to become %q, got %q.", s, GetWffString(cwffG), GetWffString(cwff))
+			case !IsACCanonical(cwff):
+				t.Errorf("\nFAILED: Expected %q to be AC-canonical.", GetWffString(cwff))
+			default:
+				t.Logf("\nPASSED: %q becomes %q.", s, GetWffString(cwff))
+			}
+
+			cwffG = cwff
+		}
+
+		if slices.Contains(cwffs, cwffG) {
+			t.Errorf("\nFAILED: Expected %q to be unlike the formulae of the other groups.", GetWffString(cwffG))
+		}
+
+		cwffs = append(cwffs, cwffG)
+	}
+
+	if wff, err = ParseWff("Q∧P"); err != nil {
+		t.Fatalf("\nFAILED: %v", err)
+	}
+
+	if IsACCanonical(wff) {
+		t.Errorf("\nFAILED: Expected Q∧P not to be AC-canonical.")
+	}
+}
+
+func TestACCanonicalWffs(t *testing.T) {
+	var (
+		wffs, wffsC []*WffTree
+		wff         *WffTree
+	)
+
+	wffs = slices.Collect(ACCanonicalWffs(CompositeWffs(1, 2, 0, 0)))
+
+	for wff = range KeepACCanonicalWffs(BuildCompositeWffs(1, 2, 0, 0)) {
+		wffsC = append(wffsC, wff)
+	}
+
+	if len(wffs) == 0 || !slices.Equal(wffs, wffsC) {
+		t.Errorf("\nFAILED: Expected the channel to send the %d AC-canonical formulae, got %d.", len(wffs), len(wffsC))
+	} else {
+		t.Logf("\nPASSED: Both yield the same %d AC-canonical formulae.", len(wffs))
+	}
+}
@@ -0,0 +1,55 @@
+package fmla
+
+import (
+	"cmp"
+	"strings"
+)
+
+// Equal reports whether two formulae are the same tree. Interned formulae are equal just when
+// they are the same pointer; for a copy made by value, as UnmarshalJSON makes, the hashes
+// settle most unequal pairs at once, and equal hashes are confirmed node by node.
+func Equal(wffA, wffB *WffTree) (is bool) {
+	if wffA == nil || wffB == nil {
+		panic("Invalid WffTree")
+	}
+
+	is = wffA == wffB || (wffA.h == wffB.h && Compare(wffA, wffB) == 0)
+
+	return
+}
+
+// Compare orders formulae totally, returning -1, 0 or +1 as wffA comes before, with, or after wffB.
+// Formulae are ordered first by kind, then by main operator, bound variable, predicate and arguments,
+// then by their left and then right subformulae.
+func Compare(wffA, wffB *WffTree) (comp int) {
+	if wffA == nil || wffB == nil {
+		panic("Invalid WffTree")
+	}
+
+	if wffA == wffB {
+		return
+	}
+
+	if comp = cmp.Or(
+		cmp.Compare(wffA.kind, wffB.kind),
+		cmp.Compare(wffA.mop, wffB.mop),
+		cmp.Compare(wffA.pVar, wffB.pVar),
+		cmp.Compare(wffA.aVar, wffB.aVar),
+		cmp.Compare(wffA.pred, wffB.pred),
+		strings.Compare(string(wffA.args), string(wffB.args)),
+	); comp != 0 {
+		return
+	}
+
+	if wffA.subL != nil {
+		if comp = Compare(wffA.subL, wffB.subL); comp != 0 {
+			return
+		}
+	}
+
+	if wffA.subR != nil {
+		comp = Compare(wffA.subR, wffB.subR)
+	}
+
+	return
+}
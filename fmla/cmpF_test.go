@@ -0,0 +1,61 @@
+package fmla
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestEqualAndCompare(t *testing.T) {
+	var (
+		wffA, wffB *WffTree
+		wffC       WffTree
+		wffs       []*WffTree
+		ss         []string
+		s          string
+		wff        *WffTree
+		expected   []string
+	)
+
+	wffA, _ = ParseWff("∀x(Fx→Gx)")
+	wffB, _ = ParseWff("∀x(Fx→Hx)")
+
+	if !Equal(wffA, DeepCopy(wffA)) || Compare(wffA, DeepCopy(wffA)) != 0 {
+		t.Errorf("\nFAILED: Expected %q to equal its copy.", GetWffString(wffA))
+	}
+
+	// Force a hash collision, which Equal must see through, on a copy that isn't interned,
+	// since the interned node is shared with every other formula ∀x(Fx→Hx).
+	wffC = *wffB
+
+	wffC.h = wffA.h
+
+	if Equal(wffA, &wffC) || IsIdentical(wffA, &wffC) {
+		t.Errorf("\nFAILED: Expected %q and %q to differ despite equal hashes.", GetWffString(wffA), GetWffString(&wffC))
+	} else {
+		t.Logf("\nPASSED: %q and %q differ despite equal hashes.", GetWffString(wffA), GetWffString(&wffC))
+	}
+
+	if wffB.h == wffA.h {
+		t.Errorf("\nFAILED: Expected the interned %q to keep its own hash.", GetWffString(wffB))
+	}
+
+	for _, s = range []string{"P∧Q", "∀xFx", "¬P", "P", "P∧P", "∃xFx", "Q", "Fa", "□P"} {
+		wff, _ = ParseWff(s)
+
+		wffs = append(wffs, wff)
+	}
+
+	slices.SortFunc(wffs, Compare)
+
+	for _, wff = range wffs {
+		ss = append(ss, GetWffString(wff))
+	}
+
+	expected = []string{"Fa", "P", "Q", "¬P", "□P", "P∧P", "P∧Q", "∀xFx", "∃xFx"}
+
+	if !slices.Equal(ss, expected) {
+		t.Errorf("\nFAILED: Expected the order %q, got %q.", expected, ss)
+	} else {
+		t.Logf("\nPASSED: Sorted into %q.", ss)
+	}
+}
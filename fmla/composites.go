@@ -1,6 +1,7 @@
 package fmla
 
 import (
+	"context"
 	"slices"
 )
 
@@ -26,26 +27,13 @@ func NewCompositeWff(sym Symbol, subL, subR *WffTree, pv Predicate, av Argument)
 			panic("Missing subformula.")
 		}
 
-		wff = &WffTree{
-			kind: Unary,
-			mop:  sym,
-			subL: DeepCopy(subL),
-		}
-
-		wff.subL.sup = wff
+		wff = intern(nodeKey{kind: Unary, mop: sym, subL: DeepCopy(subL)})
 	case slices.Contains(BinaryOps, sym):
 		if subL == nil || subR == nil {
 			panic("Missing subformulae.")
 		}
 
-		wff = &WffTree{
-			kind: Binary,
-			mop:  sym,
-			subL: DeepCopy(subL),
-			subR: DeepCopy(subR),
-		}
-
-		wff.subL.sup, wff.subR.sup = wff, wff
+		wff = intern(nodeKey{kind: Binary, mop: sym, subL: DeepCopy(subL), subR: DeepCopy(subR)})
 	case slices.Contains(Quantifiers, sym):
 		if pv == 0 && av == 0 {
 			panic("No constant over which to quantify.")
@@ -59,23 +47,11 @@ func NewCompositeWff(sym Symbol, subL, subR *WffTree, pv Predicate, av Argument)
 			panic("Missing subformula.")
 		}
 
-		wff = &WffTree{
-			kind: Quantified,
-			mop:  sym,
-			subL: DeepCopy(subL),
-		}
-
-		if pv != 0 {
-			wff.pVar = pv
-		} else {
-			wff.aVar = av
-		}
-
-		wff.subL.sup = wff
+		wff = intern(nodeKey{kind: Quantified, mop: sym, pVar: pv, aVar: av, subL: DeepCopy(subL)})
+	default:
+		panic("Invalid symbol.")
 	}
 
-	wff.h = hashWff(wff)
-
 	return
 }
 
@@ -113,51 +89,10 @@ func NewUnaryChainWff(syms []Symbol, wff *WffTree) (wffC *WffTree) {
 	return
 }
 
+// BuildCompositeWffs sends the formulae of CompositeWffs on a channel, which must be drained;
+// otherwise, range over CompositeWffs or use BuildCompositeWffsContext instead.
 func BuildCompositeWffs(nest uint, domP uint, domA uint, arity uint) (wffs chan *WffTree) {
-	switch nest {
-	case 0:
-		wffs = BuildMixedAtomicWffs(domP, domA, arity)
-	default:
-		wffs = make(chan *WffTree)
-
-		go func(n, dP, dA, ity uint) {
-			var (
-				subsL, subsR chan *WffTree
-				subL, subR   *WffTree
-				sym          Symbol
-				pc           Predicate
-				ac           Argument
-			)
-
-			subsL = BuildCompositeWffs(n-1, dP, dA, ity)
-
-			for subL = range subsL {
-				for _, sym = range UnaryOps {
-					wffs <- NewCompositeWff(sym, subL, nil, 0, 0)
-				}
-
-				for _, sym = range Quantifiers {
-					for _, pc = range PredConsts[:dP+1] {
-						wffs <- NewCompositeWff(sym, subL, nil, pc, 0)
-					}
-
-					for _, ac = range ArgConsts[:dA+1] {
-						wffs <- NewCompositeWff(sym, subL, nil, 0, ac)
-					}
-				}
-
-				subsR = BuildCompositeWffs(n-1, dP, dA, ity)
-
-				for subR = range subsR {
-					for _, sym = range BinaryOps {
-						wffs <- NewCompositeWff(sym, subL, subR, 0, 0)
-					}
-				}
-			}
-
-			close(wffs)
-		}(nest, domP, domA, arity)
-	}
+	wffs = seqToChan(context.Background(), CompositeWffs(nest, domP, domA, arity))
 
 	return
 }
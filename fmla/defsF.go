@@ -2,6 +2,7 @@ package fmla
 
 import (
 	"slices"
+	"sync/atomic"
 	"unicode/utf8"
 )
 
@@ -26,9 +27,10 @@ const (
 	// Modal Operators
 	Box     Symbol = '□'
 	Diamond Symbol = '◇'
-	// Parentheses
-	LPar Symbol = '('
-	RPar Symbol = ')'
+	// Parentheses and Separators
+	LPar  Symbol = '('
+	RPar  Symbol = ')'
+	Comma Symbol = ','
 	// Primitive Operands
 	Equals Predicate = '='
 	Top    Predicate = '⊤'
@@ -41,6 +43,10 @@ var PredVars = []Predicate("UVWXYZ")
 var ArgConsts = []Argument("abcdefghijklmnopqrst")
 var ArgVars = []Argument("uvwxyz")
 
+// Schema letters, which parse only with ParseOptions.Schemata.
+var FormulaMetas = []Predicate("φψχθ")
+var TermMetas = []Argument("τσρ")
+
 var UnaryOps = []Symbol{Neg, Box, Diamond}
 var BinaryOps = []Symbol{Wedge, Vee, To, Iff}
 var Quantifiers = []Symbol{Exists, ForAll}
@@ -54,6 +60,8 @@ const (
 	Quantified
 )
 
+// A WffTree is an immutable, interned formula node. The constructors return the one node
+// for each formula, so equal formulae share a pointer and their subformulae are shared too.
 type WffTree struct {
 	kind WffKind   // A kind of formula is Atomic, Unary, Binary, or Quantified.
 	mop  Symbol    // If Kind is Unary, Binary, or Quantified, this is the main operator.
@@ -63,8 +71,9 @@ type WffTree struct {
 	args ArgString // If Kind is Atomic, this is the tuple of arguments.
 	subL *WffTree  // If Kind is Unary, this is the sole operand; if Kind is Binary, this is the left operand.
 	subR *WffTree  // If Kind is Binary, this is the right operand.
-	sup  *WffTree  // If SubL is non-nil, this is the super-formula.
 	h    uint64    // The hash value of the WffTree.
+
+	alpha atomic.Value // The alphaForm of the WffTree, once AlphaHash or AlphaEquivalent has needed it.
 }
 
 func argStringToArgs(s ArgString) (args []Argument) {
@@ -91,6 +100,22 @@ func argsToArgString(args ...Argument) (s ArgString) {
 	return
 }
 
+// dedupe keeps the first occurrence of each element, in order. Checking for later duplicates
+// inside slices.DeleteFunc looked at a slice that was being compacted, and lost elements.
+func dedupe[T comparable](sl []T) (slD []T) {
+	var (
+		e T
+	)
+
+	for _, e = range sl {
+		if !slices.Contains(slD, e) {
+			slD = append(slD, e)
+		}
+	}
+
+	return
+}
+
 func GetWffKind(wff *WffTree) (kind WffKind) {
 	if wff == nil {
 		panic("Invalid WffTree")
@@ -126,18 +151,20 @@ func GetWffSubformulae(wff *WffTree) (subL, subR *WffTree) {
 		panic("Invalid WffTree")
 	}
 
-	subL, subR = DeepCopy(wff.subL), DeepCopy(wff.subR)
+	subL, subR = wff.subL, wff.subR
 
 	return
 }
 
+// GetWffSuperformula returns nil. Interned nodes are shared between formulae,
+// so none has a superformula of its own; use a Zipper to keep the way back up.
+//
+// Deprecated: Use Zipper.Up.
 func GetWffSuperformula(wff *WffTree) (sup *WffTree) {
 	if wff == nil {
 		panic("Invalid WffTree")
 	}
 
-	sup = DeepCopy(wff.sup)
-
 	return
 }
 
@@ -163,12 +190,12 @@ func GetConstants(wff *WffTree) (pcs []Predicate, acs []Argument) {
 
 	switch wff.kind {
 	case Atomic:
-		if 'A'-1 < wff.pred && wff.pred < 'T'+1 {
+		if IsPredConst(wff.pred) {
 			pcs = append(pcs, wff.pred)
 		}
 
 		for _, ac = range argStringToArgs(wff.args) {
-			if 'a'-1 < ac && ac < 't'+1 {
+			if IsArgConst(ac) {
 				acs = append(acs, ac)
 			}
 		}
@@ -198,21 +225,9 @@ func GetConstants(wff *WffTree) (pcs []Predicate, acs []Argument) {
 		panic("Invalid WffTree")
 	}
 
-	pcs = slices.DeleteFunc(pcs, func(pc Predicate) (nix bool) {
-		var dex int = slices.Index(pcs, pc)
-
-		nix = -1 < dex && slices.Contains(pcs[dex+1:], pc)
-
-		return
-	})
-
-	acs = slices.DeleteFunc(acs, func(ac Argument) (nix bool) {
-		var dex int = slices.Index(acs, ac)
+	pcs = dedupe(pcs)
 
-		nix = -1 < dex && slices.Contains(acs[dex+1:], ac)
-
-		return
-	})
+	acs = dedupe(acs)
 
 	return
 }
@@ -226,12 +241,12 @@ func GetVariables(wff *WffTree) (pvs []Predicate, avs []Argument) {
 
 	switch wff.kind {
 	case Atomic:
-		if 'U'-1 < wff.pred && wff.pred < 'Z'+1 {
+		if IsPredVar(wff.pred) {
 			pvs = append(pvs, wff.pred)
 		}
 
 		for _, av = range argStringToArgs(wff.args) {
-			if 'u'-1 < av && av < 'z'+1 {
+			if IsArgVar(av) {
 				avs = append(avs, av)
 			}
 		}
@@ -269,21 +284,9 @@ func GetVariables(wff *WffTree) (pvs []Predicate, avs []Argument) {
 		panic("Invalid WffTree")
 	}
 
-	pvs = slices.DeleteFunc(pvs, func(pv Predicate) (nix bool) {
-		var dex int = slices.Index(pvs, pv)
-
-		nix = -1 < dex && slices.Contains(pvs[dex+1:], pv)
-
-		return
-	})
-
-	avs = slices.DeleteFunc(avs, func(av Argument) (nix bool) {
-		var dex int = slices.Index(avs, av)
+	pvs = dedupe(pvs)
 
-		nix = -1 < dex && slices.Contains(avs[dex+1:], av)
-
-		return
-	})
+	avs = dedupe(avs)
 
 	return
 }
@@ -297,12 +300,12 @@ func GetFreeVariables(wff *WffTree) (pvs []Predicate, avs []Argument) {
 
 	switch wff.kind {
 	case Atomic:
-		if 'U'-1 < wff.pred && wff.pred < 'Z'+1 {
+		if IsPredVar(wff.pred) {
 			pvs = append(pvs, wff.pred)
 		}
 
 		for _, av = range argStringToArgs(wff.args) {
-			if 'u'-1 < av && av < 'z'+1 {
+			if IsArgVar(av) {
 				avs = append(avs, av)
 			}
 		}
@@ -350,85 +353,58 @@ func GetFreeVariables(wff *WffTree) (pvs []Predicate, avs []Argument) {
 		panic("Invalid WffTree")
 	}
 
-	pvs = slices.DeleteFunc(pvs, func(pv Predicate) (nix bool) {
-		var dex int = slices.Index(pvs, pv)
-
-		nix = -1 < dex && slices.Contains(pvs[dex+1:], pv)
-
-		return
-	})
-
-	avs = slices.DeleteFunc(avs, func(av Argument) (nix bool) {
-		var dex int = slices.Index(avs, av)
+	pvs = dedupe(pvs)
 
-		nix = -1 < dex && slices.Contains(avs[dex+1:], av)
-
-		return
-	})
+	avs = dedupe(avs)
 
 	return
 }
 
-func GetWffString(wff *WffTree) (s string) {
+func getAtomicString(wff *WffTree) (s string) {
 	var (
-		wffL, wffR string
-		lenA       int
+		ts []*Term
+		t  *Term
 	)
 
-	switch wff.kind {
-	case Atomic:
-		switch wff.pred {
-		case Top, Bot:
-			s = string(wff.pred)
-		case Equals:
-			if lenA = len(argStringToArgs(wff.args)); lenA != 2 {
-				panic("Equals predicate requires exactly two arguments")
-			}
-
-			s = string(wff.args[0]) + string(wff.pred) + string(wff.args[1])
-		default:
-			s = string(wff.pred) + string(wff.args)
-		}
-	case Unary:
-		if wff.subL.kind == Binary {
-			s = string(wff.mop) + "(" + GetWffString(wff.subL) + ")"
-		} else {
-			s = string(wff.mop) + GetWffString(wff.subL)
+	switch wff.pred {
+	case Top, Bot:
+		s = string(wff.pred)
+	case Equals:
+		if ts = argStringToTerms(wff.args); len(ts) != 2 {
+			panic("Equals predicate requires exactly two arguments")
 		}
 
-	case Binary:
-		if wff.subL.kind == Binary {
-			wffL = "(" + GetWffString(wff.subL) + ")"
-		} else {
-			wffL = GetWffString(wff.subL)
-		}
+		s = GetTermString(ts[0]) + string(wff.pred) + GetTermString(ts[1])
+	default:
+		s = wff.pred.String()
 
-		if wff.subR.kind == Binary {
-			wffR = "(" + GetWffString(wff.subR) + ")"
-		} else {
-			wffR = GetWffString(wff.subR)
+		for _, t = range argStringToTerms(wff.args) {
+			s += GetTermString(t)
 		}
+	}
 
-		s = wffL + string(wff.mop) + wffR
-	case Quantified:
-		if wff.subL.kind == Binary {
-			wffL = "(" + GetWffString(wff.subL) + ")"
-		} else {
-			wffL = GetWffString(wff.subL)
-		}
+	return
+}
 
-		if wff.pVar != 0 {
-			s = string(wff.mop) + string(wff.pVar) + wffL
-		} else if wff.aVar != 0 {
-			s = string(wff.mop) + string(wff.aVar) + wffL
-		}
-	default:
-		panic("Invalid WffTree")
+func getOperatorString(wff *WffTree) (s string) {
+	switch {
+	case wff.kind == Unary:
+		s = string(wff.mop)
+	case wff.pVar != 0:
+		s = string(wff.mop) + wff.pVar.String()
+	case wff.aVar != 0:
+		s = string(wff.mop) + wff.aVar.String()
 	}
 
 	return
 }
 
+func GetWffString(wff *WffTree) (s string) {
+	s = GetMinimalWffString(wff, strictOptions)
+
+	return
+}
+
 func GetWffLength(wff *WffTree) (lenW uint) {
 	var s string = GetWffString(wff)
 
@@ -458,6 +434,22 @@ func GetWffDepth(wff *WffTree) (depW uint) {
 	return
 }
 
+// GetWffSize returns the number of nodes in a formula, counting each atomic formula as one.
+func GetWffSize(wff *WffTree) (size uint) {
+	switch wff.kind {
+	case Atomic:
+		size = 1
+	case Unary, Quantified:
+		size = GetWffSize(wff.subL) + 1
+	case Binary:
+		size = GetWffSize(wff.subL) + GetWffSize(wff.subR) + 1
+	default:
+		panic("Invalid WffTree")
+	}
+
+	return
+}
+
 func HasPred(wff *WffTree, pred Predicate) (has bool) {
 	if wff == nil {
 		panic("Invalid WffTree")
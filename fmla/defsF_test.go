@@ -0,0 +1,48 @@
+package fmla
+
+import (
+	"testing"
+)
+
+func TestGetSymbols(t *testing.T) {
+	type testCase struct {
+		s        string
+		depth    int // How many times to take the left subformula, so that it may have free variables.
+		get      func(*WffTree) ([]Predicate, []Argument)
+		expected string // Each symbol once, in order of first occurrence.
+		was      string // What the old in-place slices.DeleteFunc dedup returned.
+	}
+
+	var (
+		tc       testCase
+		testName string
+		wff      *WffTree
+		ps       []Predicate
+		as       []Argument
+		s        string
+	)
+
+	for testName, tc = range map[string]testCase{
+		"constants repeated":       {"(P→Q)∧(P→Q)", 0, GetConstants, "PQ", ""},
+		"constants reversed":       {"Fab∧Gba", 0, GetConstants, "FGab", "FG"},
+		"constants reappear":       {"(P∧Q)∧P", 0, GetConstants, "PQ", "QP"},
+		"variables":                {"∀x∃y(Fxy∧Gyx)", 0, GetVariables, "xy", "xy"},
+		"predicate variables":      {"∀X∀Y((X∧Y)∧(Y∧X))", 0, GetVariables, "XY", "XY"},
+		"free variables":           {"∀x∃y(Fxy∧Gyx)", 2, GetFreeVariables, "xy", ""},
+		"free predicate variables": {"∀X∀Y((X∧Y)∧(Y∧X))", 2, GetFreeVariables, "XY", ""},
+	} {
+		wff, _ = ParseWff(tc.s)
+
+		for range tc.depth {
+			wff, _ = GetWffSubformulae(wff)
+		}
+
+		ps, as = tc.get(wff)
+
+		if s = string(ps) + string(as); s != tc.expected {
+			t.Errorf("\nFAILED %s: Expected %q from %q, got %q.", testName, tc.expected, tc.s, s)
+		} else {
+			t.Logf("\nPASSED %s: %q from %q, where the old dedup gave %q.", testName, s, tc.s, tc.was)
+		}
+	}
+}
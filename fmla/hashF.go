@@ -1,10 +1,13 @@
 package fmla
 
 import (
+	"encoding/binary"
 	"hash"
 	"hash/fnv"
 )
 
+// hashWff hashes a node from its own fields and the hashes of its subformulae,
+// which are interned, and so hashed, before it.
 func hashWff(wff *WffTree) (h uint64) {
 	if wff == nil {
 		panic("Invalid WffTree")
@@ -26,24 +29,24 @@ func hashWffInto(hash64 hash.Hash64, wff *WffTree) {
 	switch wff.kind {
 
 	case Atomic:
-		hash64.Write([]byte{byte(wff.pred)})
+		hash64.Write([]byte(string(wff.pred)))
 		hash64.Write([]byte(wff.args))
 
 	case Unary:
-		hash64.Write([]byte{byte(wff.mop)})
+		hash64.Write([]byte(string(wff.mop)))
 
-		hashWffInto(hash64, wff.subL)
+		hash64.Write(binary.LittleEndian.AppendUint64(nil, wff.subL.h))
 	case Binary:
-		hash64.Write([]byte{byte(wff.mop)})
+		hash64.Write([]byte(string(wff.mop)))
 
-		hashWffInto(hash64, wff.subL)
-		hashWffInto(hash64, wff.subR)
+		hash64.Write(binary.LittleEndian.AppendUint64(nil, wff.subL.h))
+		hash64.Write(binary.LittleEndian.AppendUint64(nil, wff.subR.h))
 	case Quantified:
-		hash64.Write([]byte{byte(wff.mop)})
-		hash64.Write([]byte{byte(wff.pVar)})
-		hash64.Write([]byte{byte(wff.aVar)})
+		hash64.Write([]byte(string(wff.mop)))
+		hash64.Write([]byte(string(wff.pVar)))
+		hash64.Write([]byte(string(wff.aVar)))
 
-		hashWffInto(hash64, wff.subL)
+		hash64.Write(binary.LittleEndian.AppendUint64(nil, wff.subL.h))
 	default:
 		panic("invalid WffKind")
 	}
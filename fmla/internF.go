@@ -0,0 +1,161 @@
+package fmla
+
+import (
+	"runtime"
+	"sync"
+	"weak"
+)
+
+// nodeKey holds what makes two nodes the same formula. Subformulae are interned
+// before their superformulae, so comparing their pointers compares them in full.
+type nodeKey struct {
+	kind WffKind
+	mop  Symbol
+	pVar Predicate
+	aVar Argument
+	pred Predicate
+	args ArgString
+	subL *WffTree
+	subR *WffTree
+}
+
+// The intern table holds every live node weakly, so a formula nobody refers to can still be collected.
+// It is split into shards by key, so that goroutines building formulae seldom wait on one another,
+// and a node already in its shard is found under a read lock.
+const internShardBits = 6
+
+const internShards = 1 << internShardBits
+
+type internShard struct {
+	mu    sync.RWMutex
+	table map[nodeKey]weak.Pointer[WffTree]
+}
+
+// internEntry is what the cleanup of a collected node needs to find its entry again.
+type internEntry struct {
+	shard *internShard
+	key   nodeKey
+}
+
+var internTable [internShards]internShard
+
+func keyOf(wff *WffTree) (key nodeKey) {
+	key = nodeKey{wff.kind, wff.mop, wff.pVar, wff.aVar, wff.pred, wff.args, wff.subL, wff.subR}
+
+	return
+}
+
+// shard picks the key's shard from its own fields and the hashes its subformulae already have,
+// which is cheaper than hashing the node in full before knowing whether it must be built.
+func (key nodeKey) shard() (dex uint64) {
+	var (
+		dexA int
+	)
+
+	dex = uint64(key.kind)<<56 ^ uint64(key.mop)<<32 ^ uint64(key.pVar)<<16 ^ uint64(key.aVar) ^ uint64(key.pred)<<40
+
+	for dexA = 0; dexA < len(key.args); dexA += 1 {
+		dex = dex*31 + uint64(key.args[dexA])
+	}
+
+	if key.subL != nil {
+		dex = dex*31 + key.subL.h
+	}
+
+	if key.subR != nil {
+		dex = dex*31 + key.subR.h
+	}
+
+	// Fibonacci hashing spreads the bits, so that the top ones pick among the shards.
+	dex = (dex * 0x9E3779B97F4A7C15) >> (64 - internShardBits)
+
+	return
+}
+
+// lookup returns the live node for the key in the shard, or nil if there is none.
+// The caller holds the shard's lock.
+func (sh *internShard) lookup(key nodeKey) (wff *WffTree) {
+	var (
+		wp weak.Pointer[WffTree]
+		ok bool
+	)
+
+	if wp, ok = sh.table[key]; ok {
+		wff = wp.Value()
+	}
+
+	return
+}
+
+// intern returns the one live node with the given fields, building it if there is none.
+// Every WffTree is built through intern and never changed afterwards, so equal formulae share a pointer.
+func intern(key nodeKey) (wff *WffTree) {
+	var (
+		sh *internShard
+	)
+
+	sh = &internTable[key.shard()]
+
+	sh.mu.RLock()
+	wff = sh.lookup(key)
+	sh.mu.RUnlock()
+
+	if wff != nil {
+		return
+	}
+
+	sh.mu.Lock()
+	defer sh.mu.Unlock()
+
+	// Another goroutine may have built the node between the two locks.
+	if wff = sh.lookup(key); wff != nil {
+		return
+	}
+
+	if sh.table == nil {
+		sh.table = map[nodeKey]weak.Pointer[WffTree]{}
+	}
+
+	wff = &WffTree{
+		kind: key.kind,
+		mop:  key.mop,
+		pVar: key.pVar,
+		aVar: key.aVar,
+		pred: key.pred,
+		args: key.args,
+		subL: key.subL,
+		subR: key.subR,
+	}
+
+	wff.h = hashWff(wff)
+
+	sh.table[key] = weak.Make(wff)
+
+	runtime.AddCleanup(wff, forgetNode, internEntry{sh, key})
+
+	return
+}
+
+// forgetNode drops a collected node's entry, unless a new node has taken its place.
+func forgetNode(ent internEntry) {
+	ent.shard.mu.Lock()
+	defer ent.shard.mu.Unlock()
+
+	if ent.shard.lookup(ent.key) == nil {
+		delete(ent.shard.table, ent.key)
+	}
+}
+
+func newAtomic(pred Predicate, args ArgString) (wff *WffTree) {
+	wff = intern(nodeKey{kind: Atomic, mop: NoSymbol, pred: pred, args: args})
+
+	return
+}
+
+// rebuild returns the node with the fields of wff over the given subformulae,
+// which is wff itself when they are its own.
+func rebuild(wff, subL, subR *WffTree) (wffR *WffTree) {
+	wffR = intern(nodeKey{wff.kind, wff.mop, wff.pVar, wff.aVar, wff.pred, wff.args, subL, subR})
+
+	return
+}
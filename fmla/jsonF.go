@@ -0,0 +1,242 @@
+package fmla
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+)
+
+var wffKindToName map[WffKind]string = map[WffKind]string{
+	Atomic:     "atomic",
+	Unary:      "unary",
+	Binary:     "binary",
+	Quantified: "quantified",
+}
+
+// wffJSON is the JSON schema of a WffTree. Symbols are written as GetWffString writes them,
+// so indexed symbols carry subscripts, and arguments are terms such as "f(a,b₁)".
+type wffJSON struct {
+	Kind  string   `json:"kind"`
+	Op    string   `json:"op,omitempty"`
+	Var   string   `json:"var,omitempty"`
+	Pred  string   `json:"pred,omitempty"`
+	Args  []string `json:"args,omitempty"`
+	Left  *WffTree `json:"left,omitempty"`
+	Right *WffTree `json:"right,omitempty"`
+}
+
+func (kind WffKind) String() (s string) {
+	s = wffKindToName[kind]
+
+	return
+}
+
+// parseSymbolString reads a string holding exactly one symbol, such as "x₁".
+func parseSymbolString(s string) (sym Symbol, ok bool) {
+	var (
+		syms []Symbol
+		offB int
+	)
+
+	if syms, _, _, offB = convertNotationAt(s); offB == -1 && len(syms) == 1 {
+		sym, ok = syms[0], true
+	}
+
+	return
+}
+
+// parseTermString reads a string holding exactly one term, such as "f(a,b₁)".
+func parseTermString(s string) (t *Term, ok bool) {
+	var (
+		syms []Symbol
+		offB int
+		next int
+	)
+
+	if syms, _, _, offB = convertNotationAt(s); offB == -1 {
+		t, next, ok = scanTerm(syms, 0)
+
+		ok = ok && next == len(syms)
+	}
+
+	return
+}
+
+func (wff *WffTree) MarshalJSON() (bs []byte, err error) {
+	var (
+		wj wffJSON
+		t  *Term
+	)
+
+	wj.Kind = wff.kind.String()
+
+	switch wff.kind {
+	case Atomic:
+		wj.Pred = wff.pred.String()
+
+		for _, t = range argStringToTerms(wff.args) {
+			wj.Args = append(wj.Args, GetTermString(t))
+		}
+	case Unary:
+		wj.Op, wj.Left = string(wff.mop), wff.subL
+	case Binary:
+		wj.Op, wj.Left, wj.Right = string(wff.mop), wff.subL, wff.subR
+	case Quantified:
+		wj.Op, wj.Left = string(wff.mop), wff.subL
+
+		if wff.pVar != 0 {
+			wj.Var = wff.pVar.String()
+		} else {
+			wj.Var = wff.aVar.String()
+		}
+	default:
+		err = fmt.Errorf("fmla: cannot marshal a WffTree of kind %d", wff.kind)
+
+		return
+	}
+
+	bs, err = json.Marshal(wj)
+
+	return
+}
+
+// UnmarshalJSON rebuilds the formula through the constructors,
+// so its subformulae and hash are those of a natively built WffTree.
+// Like encoding/json itself, it leaves the formula as it was for null.
+func (wff *WffTree) UnmarshalJSON(bs []byte) (err error) {
+	var (
+		wj   wffJSON
+		wffB *WffTree
+		sym  Symbol
+		bv   Symbol
+		pv   Predicate
+		av   Argument
+		s    string
+		t    *Term
+		ts   []*Term
+		ok   bool
+	)
+
+	if string(bs) == "null" {
+		return
+	}
+
+	if err = json.Unmarshal(bs, &wj); err != nil {
+		return
+	}
+
+	switch wj.Kind {
+	case Atomic.String():
+		if sym, ok = parseSymbolString(wj.Pred); !ok || !(isPredSymbol(sym) || IsFormulaMeta(Predicate(sym)) || sym == Symbol(Top) ||
+			sym == Symbol(Bot) || sym == Symbol(Equals)) {
+			err = fmt.Errorf("fmla: invalid predicate %q", wj.Pred)
+
+			return
+		}
+
+		for _, s = range wj.Args {
+			if t, ok = parseTermString(s); !ok {
+				err = fmt.Errorf("fmla: invalid term %q", s)
+
+				return
+			}
+
+			ts = append(ts, t)
+		}
+
+		if sym == Symbol(Equals) && len(ts) != 2 {
+			err = fmt.Errorf("fmla: identity takes 2 terms, not %d", len(ts))
+
+			return
+		}
+
+		wffB = NewAtomicTermWff(Predicate(sym), ts...)
+	case Unary.String(), Binary.String(), Quantified.String():
+		sym, ok = parseSymbolString(wj.Op)
+
+		switch {
+		case wj.Kind == Unary.String() && !slices.Contains(UnaryOps, sym),
+			wj.Kind == Binary.String() && !slices.Contains(BinaryOps, sym),
+			wj.Kind == Quantified.String() && !slices.Contains(Quantifiers, sym),
+			!ok:
+			err = fmt.Errorf("fmla: invalid %s operator %q", wj.Kind, wj.Op)
+
+			return
+		case wj.Left == nil, wj.Kind == Binary.String() && wj.Right == nil:
+			err = fmt.Errorf("fmla: missing subformula of %s formula", wj.Kind)
+
+			return
+		}
+
+		if wj.Kind == Quantified.String() {
+			if bv, ok = parseSymbolString(wj.Var); !ok || !isBoundVarSymbol(bv) {
+				err = fmt.Errorf("fmla: invalid bound variable %q", wj.Var)
+
+				return
+			}
+
+			if IsPredVar(Predicate(bv)) {
+				pv = Predicate(bv)
+			} else {
+				av = Argument(bv)
+			}
+		}
+
+		wffB = NewCompositeWff(sym, wj.Left, wj.Right, pv, av)
+	default:
+		err = fmt.Errorf("fmla: invalid kind %q", wj.Kind)
+
+		return
+	}
+
+	// The receiver isn't the interned node, but it shares that node's fields and subformulae.
+	*wff = *wffB
+
+	return
+}
+
+func (pred Predicate) MarshalText() (bs []byte, err error) {
+	bs = []byte(pred.String())
+
+	return
+}
+
+func (pred *Predicate) UnmarshalText(bs []byte) (err error) {
+	var (
+		sym Symbol
+		ok  bool
+	)
+
+	if sym, ok = parseSymbolString(string(bs)); !ok || !isPredSymbol(sym) {
+		err = fmt.Errorf("fmla: invalid predicate %q", bs)
+
+		return
+	}
+
+	*pred = Predicate(sym)
+
+	return
+}
+
+func (arg Argument) MarshalText() (bs []byte, err error) {
+	bs = []byte(arg.String())
+
+	return
+}
+
+func (arg *Argument) UnmarshalText(bs []byte) (err error) {
+	var (
+		sym Symbol
+		ok  bool
+	)
+
+	if sym, ok = parseSymbolString(string(bs)); !ok || !isArgSymbol(sym) {
+		err = fmt.Errorf("fmla: invalid argument %q", bs)
+
+		return
+	}
+
+	*arg = Argument(sym)
+
+	return
+}
@@ -0,0 +1,58 @@
+package fmla
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestWffJSONRoundTrip(t *testing.T) {
+	var (
+		ss        []string
+		s         string
+		wff, wffU *WffTree
+		bs        []byte
+		err       error
+	)
+
+	ss = []string{
+		"⊤",
+		"a=f(b)",
+		"¬□(P₁∨◇Q)",
+		"∀x₂(Rf(x₂,a)→∃Y(Yx₂↔⊥))",
+	}
+
+	for _, s = range ss {
+		if wff, err = ParseWff(s); err != nil {
+			t.Fatalf("\nFAILED: %v", err)
+		}
+
+		if bs, err = json.Marshal(wff); err != nil {
+			t.Fatalf("\nFAILED: Failed to marshal %q: %v.", s, err)
+		}
+
+		wffU = &WffTree{}
+
+		if err = json.Unmarshal(bs, wffU); err != nil {
+			t.Errorf("\nFAILED: Failed to unmarshal %s: %v.", bs, err)
+
+			continue
+		}
+
+		switch {
+		case !IsIdentical(wff, wffU):
+			t.Errorf("\nFAILED: Expected %q, got %q.", s, GetWffString(wffU))
+		case wffU.subL != wff.subL || DeepCopy(wffU) != wff:
+			t.Errorf("\nFAILED: %q was not rebuilt from the interned nodes.", s)
+		default:
+			t.Logf("\nPASSED: Round-tripped %q through %s.", s, bs)
+		}
+	}
+
+	if err = json.Unmarshal([]byte("null"), wffU); err != nil || !IsIdentical(wffU, wff) {
+		t.Errorf("\nFAILED: Expected null to leave %q alone, got %q (%v).", GetWffString(wff), GetWffString(wffU), err)
+	}
+
+	if err = json.Unmarshal([]byte(`{"kind":"atomic","pred":"=","args":["a"]}`), wffU); err == nil {
+		t.Errorf("\nFAILED: Expected an error for a unary identity.")
+	}
+}
@@ -0,0 +1,91 @@
+package fmla
+
+import (
+	"strings"
+)
+
+var symbolToLaTeX map[rune]string = map[rune]string{
+	rune(Neg):     `\neg `,
+	rune(Wedge):   ` \wedge `,
+	rune(Vee):     ` \vee `,
+	rune(To):      ` \to `,
+	rune(Iff):     ` \leftrightarrow `,
+	rune(Exists):  `\exists `,
+	rune(ForAll):  `\forall `,
+	rune(Box):     `\Box `,
+	rune(Diamond): `\Diamond `,
+	rune(Top):     `\top `,
+	rune(Bot):     `\bot `,
+	rune(Equals):  ` = `,
+	rune(Comma):   `, `,
+	// Schema letters.
+	'φ': `\varphi `,
+	'ψ': `\psi `,
+	'χ': `\chi `,
+	'θ': `\theta `,
+	'τ': `\tau `,
+	'σ': `\sigma `,
+	'ρ': `\rho `,
+}
+
+// StringToLaTeX rewrites a formula in Deriver's notation, as GetWffString prints it,
+// for LaTeX's math mode. It assumes amssymb for \Box and \Diamond.
+func StringToLaTeX(s string) (sL string) {
+	var (
+		sb     strings.Builder
+		r      rune
+		ok     bool
+		l      string
+		inSubs bool
+		d      Symbol
+	)
+
+	for _, r = range s {
+		// Runs of subscript digits become a single subscript.
+		if _, ok = digitValue(Symbol(r)); ok && r > '9' {
+			if !inSubs {
+				sb.WriteString("_{")
+
+				inSubs = true
+			}
+
+			d = Symbol(r) - Symbol(subDigits[0]) + '0'
+
+			sb.WriteRune(rune(d))
+
+			continue
+		}
+
+		if inSubs {
+			sb.WriteString("}")
+
+			inSubs = false
+		}
+
+		if l, ok = symbolToLaTeX[r]; ok {
+			sb.WriteString(l)
+		} else {
+			sb.WriteRune(r)
+		}
+	}
+
+	if inSubs {
+		sb.WriteString("}")
+	}
+
+	sL = strings.TrimSpace(sb.String())
+
+	return
+}
+
+// GetWffLaTeX prints the formula for LaTeX's math mode, with the parentheses
+// GetMinimalWffString would print under opts, or GetWffString's when opts is nil.
+func GetWffLaTeX(wff *WffTree, opts *ParseOptions) (s string) {
+	if opts == nil {
+		opts = strictOptions
+	}
+
+	s = StringToLaTeX(GetMinimalWffString(wff, opts))
+
+	return
+}
@@ -0,0 +1,78 @@
+package fmla
+
+import (
+	"testing"
+)
+
+func TestGetWffLaTeX(t *testing.T) {
+	type testCase struct {
+		s        string
+		opts     *ParseOptions
+		expected string
+	}
+
+	var (
+		tcs []testCase
+		tc  testCase
+		wff *WffTree
+		s   string
+		err error
+	)
+
+	tcs = []testCase{
+		{"¬(P∧Q)", nil, `\neg (P \wedge Q)`},
+		{"(P→Q)↔(¬Q→¬P)", nil, `(P \to Q) \leftrightarrow (\neg Q \to \neg P)`},
+		{"∀x∃y(Rxy∨x=y)", nil, `\forall x\exists y(Rxy \vee x = y)`},
+		{"□P→◇P", nil, `\Box P \to \Diamond P`},
+		{"⊤∧¬⊥", nil, `\top  \wedge \neg \bot`},
+		{"P₁₂∧Fa₃", nil, `P_{12} \wedge Fa_{3}`},
+		{"(P∧Q)∧R", NewParseOptions(Precedence), `P \wedge Q \wedge R`},
+		{"(P∨Q)∧R", NewParseOptions(Precedence), `(P \vee Q) \wedge R`},
+	}
+
+	for _, tc = range tcs {
+		if wff, err = ParseWff(tc.s); err != nil {
+			t.Fatalf("\nFAILED: %v", err)
+		}
+
+		if s = GetWffLaTeX(wff, tc.opts); s != tc.expected {
+			t.Errorf("\nFAILED: Expected %q in LaTeX as %q, got %q.", tc.s, tc.expected, s)
+		} else {
+			t.Logf("\nPASSED: %q in LaTeX is %q.", tc.s, s)
+		}
+	}
+}
+
+func TestSchemaLaTeX(t *testing.T) {
+	type testCase struct {
+		s        string
+		expected string
+	}
+
+	var (
+		tcs []testCase
+		tc  testCase
+		wff *WffTree
+		s   string
+		err error
+	)
+
+	tcs = []testCase{
+		{"φ→(ψ→φ)", `\varphi  \to (\psi  \to \varphi )`},
+		{"(χ∧θ)∨¬χ", `(\chi  \wedge \theta ) \vee \neg \chi`},
+		{"∀xFx→Fτ", `\forall xFx \to F\tau`},
+		{"Rσρ", `R\sigma \rho`},
+	}
+
+	for _, tc = range tcs {
+		if wff, err = ParseSchema(tc.s); err != nil {
+			t.Fatalf("\nFAILED: %v", err)
+		}
+
+		if s = GetWffLaTeX(wff, nil); s != tc.expected {
+			t.Errorf("\nFAILED: Expected %q in LaTeX as %q, got %q.", tc.s, tc.expected, s)
+		} else {
+			t.Logf("\nPASSED: %q in LaTeX is %q.", tc.s, s)
+		}
+	}
+}
@@ -1,28 +1,12 @@
 package fmla
 
+import "slices"
+
+// DeepCopy returns the interned node of a formula. Nodes are never changed once built, so this is
+// the formula itself, unless it was copied by value outside the constructors, as by UnmarshalJSON.
 func DeepCopy(wff *WffTree) (wffC *WffTree) {
 	if wff != nil {
-		wffC = &WffTree{
-			kind: wff.kind,
-			mop:  wff.mop,
-			pVar: wff.pVar,
-			aVar: wff.aVar,
-			pred: wff.pred,
-			args: wff.args,
-			subL: DeepCopy(wff.subL),
-			subR: DeepCopy(wff.subR),
-			sup:  nil, // The parent is set below.
-			h:    wff.h,
-		}
-
-		if wffC.subL != nil {
-			wffC.subL.sup = wffC
-		}
-
-		if wffC.subR != nil {
-			wffC.subR.sup = wffC
-		}
-
+		wffC = intern(keyOf(wff))
 	}
 
 	return
@@ -33,31 +17,21 @@ func ReplacePreds(wff *WffTree, pA Predicate, pB Predicate) (wffR *WffTree) {
 		panic("Invalid WffTree")
 	}
 
-	if wff.sup == nil {
-		wffR = DeepCopy(wff)
-	} else {
-		wffR = wff
-	}
-
-	switch wffR.kind {
+	switch wff.kind {
 	case Atomic:
-		if wffR.pred == pA {
-			wffR.pred = pB
+		if wff.pred == pA {
+			wffR = newAtomic(pB, wff.args)
+		} else {
+			wffR = DeepCopy(wff)
 		}
-	case Unary:
-		wffR.subL = ReplacePreds(wffR.subL, pA, pB)
+	case Unary, Quantified:
+		wffR = rebuild(wff, ReplacePreds(wff.subL, pA, pB), nil)
 	case Binary:
-		wffR.subL = ReplacePreds(wffR.subL, pA, pB)
-
-		wffR.subR = ReplacePreds(wffR.subR, pA, pB)
-	case Quantified:
-		wffR.subL = ReplacePreds(wffR.subL, pA, pB)
+		wffR = rebuild(wff, ReplacePreds(wff.subL, pA, pB), ReplacePreds(wff.subR, pA, pB))
 	default:
 		panic("Invalid WffTree")
 	}
 
-	wffR.h = hashWff(wffR)
-
 	return
 }
 
@@ -71,17 +45,11 @@ func ReplaceArgs(wff *WffTree, aA Argument, aB Argument) (wffR *WffTree) {
 		panic("Invalid WffTree")
 	}
 
-	if wff.sup == nil {
-		wffR = DeepCopy(wff)
-	} else {
-		wffR = wff
-	}
-
-	switch wffR.kind {
+	switch wff.kind {
 	case Atomic:
 		newArgs = ArgString("")
 
-		for _, arg = range argStringToArgs(wffR.args) {
+		for _, arg = range argStringToArgs(wff.args) {
 			if arg == aA {
 				newArgs += ArgString(aB)
 			} else {
@@ -89,24 +57,52 @@ func ReplaceArgs(wff *WffTree, aA Argument, aB Argument) (wffR *WffTree) {
 			}
 		}
 
-		wffR.args = newArgs
-	case Unary:
-		wffR.subL = ReplaceArgs(wffR.subL, aA, aB)
+		wffR = newAtomic(wff.pred, newArgs)
+	case Unary, Quantified:
+		wffR = rebuild(wff, ReplaceArgs(wff.subL, aA, aB), nil)
 	case Binary:
-		wffR.subL = ReplaceArgs(wffR.subL, aA, aB)
-
-		wffR.subR = ReplaceArgs(wffR.subR, aA, aB)
-	case Quantified:
-		wffR.subL = ReplaceArgs(wffR.subL, aA, aB)
+		wffR = rebuild(wff, ReplaceArgs(wff.subL, aA, aB), ReplaceArgs(wff.subR, aA, aB))
 	default:
 		panic("Invalid WffTree")
 	}
 
-	wffR.h = hashWff(wffR)
-
 	return wffR
 }
 
+func ReplaceArgWithTerm(wff *WffTree, aA Argument, t *Term) (wffR *WffTree) {
+	var (
+		arg     Argument
+		newArgs ArgString
+	)
+
+	if wff == nil || t == nil {
+		panic("Invalid WffTree")
+	}
+
+	switch wff.kind {
+	case Atomic:
+		newArgs = ArgString("")
+
+		for _, arg = range argStringToArgs(wff.args) {
+			if arg == aA {
+				newArgs += termsToArgString(t)
+			} else {
+				newArgs += ArgString(arg)
+			}
+		}
+
+		wffR = newAtomic(wff.pred, newArgs)
+	case Unary, Quantified:
+		wffR = rebuild(wff, ReplaceArgWithTerm(wff.subL, aA, t), nil)
+	case Binary:
+		wffR = rebuild(wff, ReplaceArgWithTerm(wff.subL, aA, t), ReplaceArgWithTerm(wff.subR, aA, t))
+	default:
+		panic("Invalid WffTree")
+	}
+
+	return
+}
+
 func singleReplacements(s ArgString, aA Argument, aB Argument) (ss []ArgString) {
 	var (
 		args []Argument
@@ -141,30 +137,14 @@ func ReplaceEachArgOnce(wff *WffTree, aA Argument, aB Argument) (wffsR []*WffTre
 		panic("Invalid WffTree")
 	}
 
-	if wff.sup == nil {
-		wffC = DeepCopy(wff)
-	} else {
-		wffC = wff
-	}
+	wffC = DeepCopy(wff)
 
 	switch wffC.kind {
 	case Atomic:
 		ss = singleReplacements(wffC.args, aA, aB)
 
 		for _, s = range ss {
-			wffN = &WffTree{
-				kind: Atomic,
-				mop:  wffC.mop,
-				pVar: wffC.pVar,
-				aVar: wffC.aVar,
-				pred: wffC.pred,
-				args: s,
-				subL: DeepCopy(wffC.subL),
-				subR: DeepCopy(wffC.subR),
-				sup:  wffC.sup,
-			}
-
-			wffN.h = hashWff(wffN)
+			wffN = newAtomic(wffC.pred, s)
 
 			wffsR = append(wffsR, wffN)
 		}
@@ -207,59 +187,119 @@ func ReplaceEachArgOnce(wff *WffTree, aA Argument, aB Argument) (wffsR []*WffTre
 	return
 }
 
-func IsIdentical(wffA, wffB *WffTree) (is bool) {
-	is = wffA.h == wffB.h
+func singleTermReplacements(s ArgString, tA, tB *Term) (ss []ArgString) {
+	var (
+		args, argsA []Argument
+		sB          ArgString
+		dex, lenA   int
+	)
+
+	args, argsA, sB = argStringToArgs(s), argStringToArgs(termsToArgString(tA)), termsToArgString(tB)
+
+	lenA = len(argsA)
+
+	// Every position in a prefix-notation ArgString begins a subterm,
+	// so each match of the encoded term is one occurrence of it.
+	for dex = 0; dex+lenA < len(args)+1; dex += 1 {
+		if slices.Equal(args[dex:dex+lenA], argsA) {
+			ss = append(ss, argsToArgString(args[:dex]...)+sB+argsToArgString(args[dex+lenA:]...))
+		}
+	}
 
 	return
 }
 
-func ReplaceWff(wff, wffA, wffB *WffTree) (wffR *WffTree) {
-	if wff == nil {
+func ReplaceEachTermOnce(wff *WffTree, tA, tB *Term) (wffsR []*WffTree) {
+	var (
+		wffC, sub, wffN *WffTree
+		subLs, subRs    []*WffTree
+		ss              []ArgString
+		s               ArgString
+	)
+
+	if wff == nil || tA == nil || tB == nil {
 		panic("Invalid WffTree")
 	}
 
-	if wff.sup == nil {
-		wffR = DeepCopy(wff)
-	} else {
-		wffR = wff
-	}
+	wffC = DeepCopy(wff)
+
+	switch wffC.kind {
+	case Atomic:
+		ss = singleTermReplacements(wffC.args, tA, tB)
+
+		for _, s = range ss {
+			wffN = newAtomic(wffC.pred, s)
 
-	if IsIdentical(wffR, wffA) {
-		wffR = &WffTree{
-			kind: Atomic,
-			mop:  wffB.mop,
-			pVar: wffB.pVar,
-			aVar: wffB.aVar,
-			pred: wffB.pred,
-			args: wffB.args,
-			subL: DeepCopy(wffB.subL),
-			subR: DeepCopy(wffB.subR),
-			sup:  wffR.sup,
+			wffsR = append(wffsR, wffN)
 		}
+	case Unary:
+		subLs = ReplaceEachTermOnce(wffC.subL, tA, tB)
 
-		if wffR.subL != nil {
-			wffR.subL.sup = wffR
+		for _, sub = range subLs {
+			wffN = NewCompositeWff(wffC.mop, sub, nil, 0, 0)
+
+			wffsR = append(wffsR, wffN)
 		}
+	case Binary:
+		subLs = ReplaceEachTermOnce(wffC.subL, tA, tB)
 
-		if wffR.subR != nil {
-			wffR.subR.sup = wffR
+		for _, sub = range subLs {
+			wffN = NewCompositeWff(wffC.mop, sub, wffC.subR, 0, 0)
+
+			wffsR = append(wffsR, wffN)
 		}
-	} else {
-		switch wffR.kind {
-		case Atomic:
-			// There are no sub-formulae to check.
-		case Unary, Quantified:
-			wffR.subL = ReplaceWff(wffR.subL, wffA, wffB)
-		case Binary:
-			wffR.subL = ReplaceWff(wffR.subL, wffA, wffB)
-
-			wffR.subR = ReplaceWff(wffR.subR, wffA, wffB)
-		default:
-			panic("Invalid WffTree")
+
+		subRs = ReplaceEachTermOnce(wffC.subR, tA, tB)
+
+		for _, sub = range subRs {
+			wffN = NewCompositeWff(wffC.mop, wffC.subL, sub, 0, 0)
+
+			wffsR = append(wffsR, wffN)
+		}
+	case Quantified:
+		subLs = ReplaceEachTermOnce(wffC.subL, tA, tB)
+
+		for _, sub = range subLs {
+			wffN = NewCompositeWff(wffC.mop, sub, nil, wffC.pVar, wffC.aVar)
+
+			wffsR = append(wffsR, wffN)
 		}
+	default:
+		panic("Invalid WffTree")
+	}
+
+	return
+}
+
+// IsIdentical reports whether two formulae are the same tree, as Equal does.
+func IsIdentical(wffA, wffB *WffTree) (is bool) {
+	is = Equal(wffA, wffB)
+
+	return
+}
+
+func ReplaceWff(wff, wffA, wffB *WffTree) (wffR *WffTree) {
+	if wff == nil {
+		panic("Invalid WffTree")
+	}
+
+	if IsIdentical(wff, wffA) {
+		wffR = DeepCopy(wffB)
+
+		return
 	}
 
-	wffR.h = hashWff(wffR)
+	switch wff.kind {
+	case Atomic:
+		// There are no sub-formulae to check.
+		wffR = DeepCopy(wff)
+	case Unary, Quantified:
+		wffR = rebuild(wff, ReplaceWff(wff.subL, wffA, wffB), nil)
+	case Binary:
+		wffR = rebuild(wff, ReplaceWff(wff.subL, wffA, wffB), ReplaceWff(wff.subR, wffA, wffB))
+	default:
+		panic("Invalid WffTree")
+	}
 
 	return
 }
@@ -306,11 +346,13 @@ func Instantiate(wff *WffTree, pred Predicate, arg Argument) (wffI *WffTree) {
 		panic("WffTree is not a quantified formula.")
 	}
 
+	// The instance is the scope of the quantifier, not the quantified formula,
+	// with only the occurrences that the quantifier binds replaced.
 	switch {
 	case wff.pVar != 0 && pred != 0:
-		wffI = ReplacePreds(wff, wff.pVar, pred)
+		wffI = SubstitutePred(wff.subL, wff.pVar, pred)
 	case wff.aVar != 0 && arg != 0:
-		wffI = ReplaceArgs(wff, wff.aVar, arg)
+		wffI = SubstituteTerm(wff.subL, wff.aVar, NewArgTerm(arg))
 	default:
 		panic("Parameters cannot qualify for instantiation.")
 	}
@@ -318,6 +360,20 @@ func Instantiate(wff *WffTree, pred Predicate, arg Argument) (wffI *WffTree) {
 	return
 }
 
+func InstantiateTerm(wff *WffTree, t *Term) (wffI *WffTree) {
+	if wff == nil || t == nil {
+		panic("Invalid WffTree")
+	}
+
+	if wff.kind != Quantified || wff.aVar == 0 {
+		panic("WffTree is not quantified over an argument variable.")
+	}
+
+	wffI = SubstituteTerm(wff.subL, wff.aVar, t)
+
+	return
+}
+
 func GeneralizePred(mop Symbol, wff *WffTree, pred, pVar Predicate) (wffP *WffTree) {
 	var subL *WffTree
 
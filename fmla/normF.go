@@ -0,0 +1,403 @@
+package fmla
+
+import (
+	"slices"
+)
+
+// A quantifier is one link of a prenex prefix.
+type quantifier struct {
+	mop  Symbol
+	pVar Predicate
+	aVar Argument
+}
+
+var dualSymbols map[Symbol]Symbol = map[Symbol]Symbol{
+	Wedge:   Vee,
+	Vee:     Wedge,
+	ForAll:  Exists,
+	Exists:  ForAll,
+	Box:     Diamond,
+	Diamond: Box,
+}
+
+func negateWff(wff *WffTree) (wffN *WffTree) {
+	switch {
+	case wff.kind == Atomic && wff.pred == Top:
+		wffN = NewAtomicWff(Bot)
+	case wff.kind == Atomic && wff.pred == Bot:
+		wffN = NewAtomicWff(Top)
+	default:
+		wffN = NewCompositeWff(Neg, wff, nil, 0, 0)
+	}
+
+	return
+}
+
+// toNNF returns the negation normal form of wff, or of its negation if neg.
+func toNNF(wff *WffTree, neg bool) (wffN *WffTree) {
+	var (
+		mop Symbol
+	)
+
+	switch mop = wff.mop; wff.kind {
+	case Atomic:
+		if wffN = DeepCopy(wff); neg {
+			wffN = negateWff(wffN)
+		}
+	case Unary:
+		switch {
+		case mop == Neg:
+			wffN = toNNF(wff.subL, !neg)
+		case neg:
+			wffN = NewCompositeWff(dualSymbols[mop], toNNF(wff.subL, true), nil, 0, 0)
+		default:
+			wffN = NewCompositeWff(mop, toNNF(wff.subL, false), nil, 0, 0)
+		}
+	case Binary:
+		switch {
+		case mop == To && neg:
+			// ¬(A→B) is A∧¬B.
+			wffN = NewCompositeWff(Wedge, toNNF(wff.subL, false), toNNF(wff.subR, true), 0, 0)
+		case mop == To:
+			// A→B is ¬A∨B.
+			wffN = NewCompositeWff(Vee, toNNF(wff.subL, true), toNNF(wff.subR, false), 0, 0)
+		case mop == Iff && neg:
+			// ¬(A↔B) is (A∧¬B)∨(¬A∧B).
+			wffN = NewCompositeWff(Vee,
+				NewCompositeWff(Wedge, toNNF(wff.subL, false), toNNF(wff.subR, true), 0, 0),
+				NewCompositeWff(Wedge, toNNF(wff.subL, true), toNNF(wff.subR, false), 0, 0), 0, 0)
+		case mop == Iff:
+			// A↔B is (¬A∨B)∧(A∨¬B).
+			wffN = NewCompositeWff(Wedge,
+				NewCompositeWff(Vee, toNNF(wff.subL, true), toNNF(wff.subR, false), 0, 0),
+				NewCompositeWff(Vee, toNNF(wff.subL, false), toNNF(wff.subR, true), 0, 0), 0, 0)
+		case neg:
+			wffN = NewCompositeWff(dualSymbols[mop], toNNF(wff.subL, true), toNNF(wff.subR, true), 0, 0)
+		default:
+			wffN = NewCompositeWff(mop, toNNF(wff.subL, false), toNNF(wff.subR, false), 0, 0)
+		}
+	case Quantified:
+		if neg {
+			mop = dualSymbols[mop]
+		}
+
+		wffN = NewCompositeWff(mop, toNNF(wff.subL, neg), nil, wff.pVar, wff.aVar)
+	default:
+		panic("Invalid WffTree")
+	}
+
+	return
+}
+
+// ToNNF returns the negation normal form of a formula, built from literals with ∧, ∨, quantifiers,
+// and modal operators alone: → and ↔ are unfolded into ∧, ∨ and ¬, and negations are pushed inward
+// by De Morgan's laws, the quantifier dualities, and the modal dualities ¬□A ⟚ ◇¬A and ¬◇A ⟚ □¬A.
+// It preserves equivalence in classical logic and in every normal modal logic, K included.
+// It does not in intuitionistic logic, where neither ¬¬A → A, ¬(A∧B) → ¬A∨¬B, nor ¬∀xA → ∃x¬A holds.
+func ToNNF(wff *WffTree) (wffN *WffTree) {
+	if wff == nil {
+		panic("Invalid WffTree")
+	}
+
+	wffN = toNNF(wff, false)
+
+	return
+}
+
+// spread joins two formulae in normal form with inner, distributing it over outer.
+func spread(wffL, wffR *WffTree, outer, inner Symbol) (wffS *WffTree) {
+	switch {
+	case wffL.kind == Binary && wffL.mop == outer:
+		wffS = NewCompositeWff(outer, spread(wffL.subL, wffR, outer, inner), spread(wffL.subR, wffR, outer, inner), 0, 0)
+	case wffR.kind == Binary && wffR.mop == outer:
+		wffS = NewCompositeWff(outer, spread(wffL, wffR.subL, outer, inner), spread(wffL, wffR.subR, outer, inner), 0, 0)
+	default:
+		wffS = NewCompositeWff(inner, wffL, wffR, 0, 0)
+	}
+
+	return
+}
+
+// distribute puts a formula in NNF into a normal form of outer over inner.
+// The scopes of quantifiers and modal operators are put in the same form, but are otherwise left be.
+func distribute(wff *WffTree, outer, inner Symbol) (wffD *WffTree) {
+	switch wff.kind {
+	case Atomic:
+		wffD = DeepCopy(wff)
+	case Unary:
+		if wff.mop == Neg {
+			wffD = DeepCopy(wff)
+		} else {
+			wffD = NewCompositeWff(wff.mop, distribute(wff.subL, outer, inner), nil, 0, 0)
+		}
+	case Binary:
+		if wff.mop == outer {
+			wffD = NewCompositeWff(outer, distribute(wff.subL, outer, inner), distribute(wff.subR, outer, inner), 0, 0)
+		} else {
+			wffD = spread(distribute(wff.subL, outer, inner), distribute(wff.subR, outer, inner), outer, inner)
+		}
+	case Quantified:
+		wffD = NewCompositeWff(wff.mop, distribute(wff.subL, outer, inner), nil, wff.pVar, wff.aVar)
+	default:
+		panic("Invalid WffTree")
+	}
+
+	return
+}
+
+// ToCNF returns the conjunctive normal form of a formula: its NNF, with ∨ distributed over ∧.
+// Quantified and modal subformulae count as literals, and their scopes are put in CNF in turn,
+// so a prenex formula keeps its prefix over a matrix in CNF.
+// Since it passes through ToNNF, it preserves equivalence only in classical logic.
+func ToCNF(wff *WffTree) (wffC *WffTree) {
+	if wff == nil {
+		panic("Invalid WffTree")
+	}
+
+	wffC = distribute(toNNF(wff, false), Wedge, Vee)
+
+	return
+}
+
+// ToDNF returns the disjunctive normal form of a formula: its NNF, with ∧ distributed over ∨.
+// Quantified and modal subformulae count as literals, as for ToCNF.
+// Since it passes through ToNNF, it preserves equivalence only in classical logic.
+func ToDNF(wff *WffTree) (wffD *WffTree) {
+	if wff == nil {
+		panic("Invalid WffTree")
+	}
+
+	wffD = distribute(toNNF(wff, false), Vee, Wedge)
+
+	return
+}
+
+func hasQuantifier(wff *WffTree) (has bool) {
+	has = slices.ContainsFunc(AllSubformulae(wff), func(sub *WffTree) (is bool) {
+		is = sub.kind == Quantified
+
+		return
+	})
+
+	return
+}
+
+// unfoldIffs rewrites each A↔B whose sides are quantified as (A→B)∧(B→A),
+// since a quantifier in either side has no single polarity.
+func unfoldIffs(wff *WffTree) (wffU *WffTree) {
+	var (
+		subL, subR *WffTree
+	)
+
+	switch wff.kind {
+	case Atomic:
+		wffU = DeepCopy(wff)
+	case Unary:
+		wffU = NewCompositeWff(wff.mop, unfoldIffs(wff.subL), nil, 0, 0)
+	case Binary:
+		subL, subR = unfoldIffs(wff.subL), unfoldIffs(wff.subR)
+
+		if wff.mop == Iff && (hasQuantifier(subL) || hasQuantifier(subR)) {
+			wffU = NewCompositeWff(Wedge,
+				NewCompositeWff(To, subL, subR, 0, 0),
+				NewCompositeWff(To, subR, subL, 0, 0), 0, 0)
+		} else {
+			wffU = NewCompositeWff(wff.mop, subL, subR, 0, 0)
+		}
+	case Quantified:
+		wffU = NewCompositeWff(wff.mop, unfoldIffs(wff.subL), nil, wff.pVar, wff.aVar)
+	default:
+		panic("Invalid WffTree")
+	}
+
+	return
+}
+
+// rectify renames bound variables apart, so that no two quantifiers bind the same variable
+// and no bound variable is also free. A variable keeps its name wherever it's still unused.
+func rectify(wff *WffTree, pMap map[Predicate]Predicate, aMap map[Argument]Argument,
+	pUsed map[Predicate]bool, aUsed map[Argument]bool) (wffR *WffTree) {
+	var (
+		pred       Predicate
+		args       []Argument
+		dex        int
+		pv, pvOld  Predicate
+		av, avOld  Argument
+		n          uint
+		hadP, hadA bool
+		ok         bool
+	)
+
+	switch wff.kind {
+	case Atomic:
+		if pred, ok = pMap[wff.pred]; !ok {
+			pred = wff.pred
+		}
+
+		args = argStringToArgs(wff.args)
+
+		for dex = range args {
+			if av, ok = aMap[args[dex]]; ok {
+				args[dex] = av
+			}
+		}
+
+		wffR = newAtomic(pred, argsToArgString(args...))
+	case Unary:
+		wffR = NewCompositeWff(wff.mop, rectify(wff.subL, pMap, aMap, pUsed, aUsed), nil, 0, 0)
+	case Binary:
+		wffR = NewCompositeWff(wff.mop, rectify(wff.subL, pMap, aMap, pUsed, aUsed),
+			rectify(wff.subR, pMap, aMap, pUsed, aUsed), 0, 0)
+	case Quantified:
+		if pv, av = wff.pVar, wff.aVar; pv != 0 {
+			for n = 0; pUsed[pv]; n += 1 {
+				pv = NthPredVar(n)
+			}
+
+			pvOld, hadP = pMap[wff.pVar]
+
+			pMap[wff.pVar], pUsed[pv] = pv, true
+		} else {
+			for n = 0; aUsed[av]; n += 1 {
+				av = NthArgVar(n)
+			}
+
+			avOld, hadA = aMap[wff.aVar]
+
+			aMap[wff.aVar], aUsed[av] = av, true
+		}
+
+		wffR = NewCompositeWff(wff.mop, rectify(wff.subL, pMap, aMap, pUsed, aUsed), nil, pv, av)
+
+		// Restore any outer renaming of the same variable once its scope ends.
+		switch {
+		case pv != 0 && hadP:
+			pMap[wff.pVar] = pvOld
+		case pv != 0:
+			delete(pMap, wff.pVar)
+		case hadA:
+			aMap[wff.aVar] = avOld
+		default:
+			delete(aMap, wff.aVar)
+		}
+	default:
+		panic("Invalid WffTree")
+	}
+
+	return
+}
+
+// rectifyFormula rectifies a whole formula, leaving the names of its free variables to them alone.
+func rectifyFormula(wff *WffTree) (wffR *WffTree) {
+	var (
+		pvs   []Predicate
+		avs   []Argument
+		pv    Predicate
+		av    Argument
+		pUsed map[Predicate]bool
+		aUsed map[Argument]bool
+	)
+
+	pUsed, aUsed = map[Predicate]bool{}, map[Argument]bool{}
+
+	pvs, avs = GetFreeVariables(wff)
+
+	for _, pv = range pvs {
+		pUsed[pv] = true
+	}
+
+	for _, av = range avs {
+		aUsed[av] = true
+	}
+
+	wffR = rectify(wff, map[Predicate]Predicate{}, map[Argument]Argument{}, pUsed, aUsed)
+
+	return
+}
+
+func dualPrefix(prefix []quantifier) (prefixD []quantifier) {
+	var (
+		q quantifier
+	)
+
+	for _, q = range prefix {
+		q.mop = dualSymbols[q.mop]
+
+		prefixD = append(prefixD, q)
+	}
+
+	return
+}
+
+// pullQuantifiers splits a rectified formula into a quantifier prefix and a quantifier-free matrix,
+// apart from the scopes of modal operators, which are put in prenex form in place.
+func pullQuantifiers(wff *WffTree) (prefix []quantifier, matrix *WffTree) {
+	var (
+		prefixL, prefixR []quantifier
+		matL, matR       *WffTree
+	)
+
+	switch wff.kind {
+	case Atomic:
+		matrix = DeepCopy(wff)
+	case Unary:
+		if wff.mop != Neg {
+			matrix = NewCompositeWff(wff.mop, wrapPrefix(pullQuantifiers(wff.subL)), nil, 0, 0)
+
+			break
+		}
+
+		prefixL, matL = pullQuantifiers(wff.subL)
+
+		prefix, matrix = dualPrefix(prefixL), NewCompositeWff(Neg, matL, nil, 0, 0)
+	case Binary:
+		prefixL, matL = pullQuantifiers(wff.subL)
+
+		prefixR, matR = pullQuantifiers(wff.subR)
+
+		// A quantifier in the antecedent of a conditional is dualized on its way out.
+		if wff.mop == To {
+			prefixL = dualPrefix(prefixL)
+		}
+
+		prefix, matrix = append(prefixL, prefixR...), NewCompositeWff(wff.mop, matL, matR, 0, 0)
+	case Quantified:
+		prefixL, matrix = pullQuantifiers(wff.subL)
+
+		prefix = append([]quantifier{{wff.mop, wff.pVar, wff.aVar}}, prefixL...)
+	default:
+		panic("Invalid WffTree")
+	}
+
+	return
+}
+
+func wrapPrefix(prefix []quantifier, matrix *WffTree) (wffW *WffTree) {
+	var (
+		q quantifier
+	)
+
+	wffW = matrix
+
+	for _, q = range slices.Backward(prefix) {
+		wffW = NewCompositeWff(q.mop, wffW, nil, q.pVar, q.aVar)
+	}
+
+	return
+}
+
+// ToPrenex returns a prenex normal form of a formula: a string of quantifiers over a quantifier-free matrix.
+// Bound variables are first renamed apart, so that pulling the quantifiers out captures none.
+// Quantifiers stay inside modal operators, since the Barcan formulae aren't valid in general,
+// but the scope of each modal operator is put in prenex form in turn.
+// It preserves equivalence in classical logic over nonempty domains, but not in intuitionistic logic,
+// where neither ¬∀xA → ∃x¬A nor (∀xA → B) → ∃x(A → B) holds.
+func ToPrenex(wff *WffTree) (wffP *WffTree) {
+	if wff == nil {
+		panic("Invalid WffTree")
+	}
+
+	wffP = wrapPrefix(pullQuantifiers(rectifyFormula(unfoldIffs(wff))))
+
+	return
+}
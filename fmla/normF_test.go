@@ -0,0 +1,52 @@
+package fmla
+
+import (
+	"testing"
+)
+
+func TestNormalForms(t *testing.T) {
+	type testCase struct {
+		s        string
+		toNF     func(wff *WffTree) (wffN *WffTree)
+		name     string
+		expected string
+	}
+
+	var (
+		tcs []testCase
+		tc  testCase
+		wff *WffTree
+		s   string
+		err error
+	)
+
+	tcs = []testCase{
+		{"¬(P→(Q∧¬R))", ToNNF, "NNF", "P∧(¬Q∨R)"},
+		{"¬□(P∨◇Q)", ToNNF, "NNF", "◇(¬P∧□¬Q)"},
+		{"¬∀x(Fx→∃yGy)", ToNNF, "NNF", "∃x(Fx∧∀y¬Gy)"},
+		{"¬(⊤∧¬⊥)", ToNNF, "NNF", "⊥∨⊥"},
+		{"(P∧Q)∨(R∧S)", ToCNF, "CNF", "((P∨R)∧(P∨S))∧((Q∨R)∧(Q∨S))"},
+		{"P↔Q", ToCNF, "CNF", "(¬P∨Q)∧(P∨¬Q)"},
+		{"¬(P∨(Q→R))", ToDNF, "DNF", "¬P∧(Q∧¬R)"},
+		{"(P∨Q)∧R", ToDNF, "DNF", "(P∧R)∨(Q∧R)"},
+		{"∀x((Fx∨Gx)∧Hx)", ToDNF, "DNF", "∀x((Fx∧Hx)∨(Gx∧Hx))"},
+		{"¬∀x(Fx→∃yGy)", ToPrenex, "PNF", "∃x∀y¬(Fx→Gy)"},
+		{"∀xFx→∀xGx", ToPrenex, "PNF", "∃x∀u(Fx→Gu)"},
+		{"∀xFx↔P", ToPrenex, "PNF", "∃x∀u((Fx→P)∧(P→Fu))"},
+		{"∀x(Fx∧∃xGx)", ToPrenex, "PNF", "∀x∃u(Fx∧Gu)"},
+		{"□∀x∃yRxy→∃zFz", ToPrenex, "PNF", "∃z(□∀x∃yRxy→Fz)"},
+		{"∀X(Xa→Xb)∧∃XXa", ToPrenex, "PNF", "∀X∃U((Xa→Xb)∧Ua)"},
+	}
+
+	for _, tc = range tcs {
+		if wff, err = ParseWff(tc.s); err != nil {
+			t.Fatalf("\nFAILED: %v", err)
+		}
+
+		if s = GetWffString(tc.toNF(wff)); s != tc.expected {
+			t.Errorf("\nFAILED: Expected the %s of %q to be %q, got %q.", tc.name, tc.s, tc.expected, s)
+		} else {
+			t.Logf("\nPASSED: The %s of %q is %q.", tc.name, tc.s, s)
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package fmla
+
+import (
+	"fmt"
+	"strings"
+)
+
+type ParseStep int
+
+const (
+	StepConvertNotation ParseStep = iota + 1
+	StepNewParser
+	StepFindMainOperator
+	StepIsClosedWff
+)
+
+type Expectation int
+
+const (
+	ExpectSymbol   Expectation = iota + 1 // A symbol of the notation.
+	ExpectOperand                         // A formula on which an operator can act.
+	ExpectOperator                        // An operator joining what precedes it to what follows it.
+	ExpectLPar                            // An opening parenthesis for a closing one.
+	ExpectRPar                            // A closing parenthesis for an opening one.
+	ExpectBoundVar                        // A predicate or argument variable for a quantifier to bind.
+	ExpectGrouping                        // Parentheses deciding which binary operator is the main one.
+	ExpectClosure                         // A quantifier binding every variable.
+)
+
+type ParseError struct {
+	Input    string      // The string that failed to parse.
+	Offset   int         // The rune offset in Input at which the parse failed.
+	Step     ParseStep   // The step of the parser that failed.
+	Expected Expectation // What the parser expected to find at Offset.
+	FreePVs  []Predicate // The free predicate variables, if Step is StepIsClosedWff.
+	FreeAVs  []Argument  // The free argument variables, if Step is StepIsClosedWff.
+}
+
+var parseStepToName map[ParseStep]string = map[ParseStep]string{
+	StepConvertNotation:  "convertNotation",
+	StepNewParser:        "newParser",
+	StepFindMainOperator: "findMainOperator",
+	StepIsClosedWff:      "isClosedWff",
+}
+
+var expectationToName map[Expectation]string = map[Expectation]string{
+	ExpectSymbol:   "a recognized symbol",
+	ExpectOperand:  "an operand",
+	ExpectOperator: "an operator",
+	ExpectLPar:     "an opening parenthesis",
+	ExpectRPar:     "a closing parenthesis",
+	ExpectBoundVar: "a bound variable",
+	ExpectGrouping: "parentheses around a binary operand",
+	ExpectClosure:  "no free variables",
+}
+
+func (step ParseStep) String() (s string) {
+	s = parseStepToName[step]
+
+	return
+}
+
+func (exp Expectation) String() (s string) {
+	s = expectationToName[exp]
+
+	return
+}
+
+func newParseError(step ParseStep, exp Expectation, off int) (perr *ParseError) {
+	perr = &ParseError{
+		Offset:   off,
+		Step:     step,
+		Expected: exp,
+	}
+
+	return
+}
+
+func (perr *ParseError) Error() (s string) {
+	var (
+		vs []string
+		pv Predicate
+		av Argument
+	)
+
+	s = fmt.Sprintf("fmla: %s: expected %s at offset %d of %q", perr.Step, perr.Expected, perr.Offset, perr.Input)
+
+	for _, pv = range perr.FreePVs {
+		vs = append(vs, pv.String())
+	}
+
+	for _, av = range perr.FreeAVs {
+		vs = append(vs, av.String())
+	}
+
+	if 0 < len(vs) {
+		s += "; free variables: " + strings.Join(vs, ", ")
+	}
+
+	return
+}
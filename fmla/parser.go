@@ -1,9 +1,9 @@
 package fmla
 
 import (
-	"regexp"
 	"slices"
 	"strings"
+	"unicode/utf8"
 )
 
 type convStruct struct {
@@ -16,67 +16,102 @@ type parser struct {
 	s    string
 	syms []Symbol
 	deps []int
+	offs []int // The rune offset of each symbol in the unconverted string.
+	end  int   // The rune offset just past the last symbol in the unconverted string.
+	opts *ParseOptions
 	wffs []*WffTree
 }
 
-var (
-	rexWS   = regexp.MustCompile(`\s`)
-	rexBase = regexp.MustCompile(`^[A-Z][a-z]*$`)
-	rexTorF = regexp.MustCompile(`^(⊤|⊥)$`)
-	rexIden = regexp.MustCompile(`^[a-z]=[a-z]$`)
-)
+var convs = []convStruct{
+	// Three-character transformations:
+	{from: "<->", sym: Iff},
+	// Two-character transformations:
+	{from: "->", sym: To},
+	{from: "\\/", sym: Vee},
+	{from: "/\\", sym: Wedge},
+	{from: "[]", sym: Box},
+	{from: "<>", sym: Diamond},
+	// One-character transformations:
+	{from: "~", sym: Neg},
+	{from: "$", sym: Exists},
+	{from: "@", sym: ForAll},
+	{from: "^", pred: Top},
+	{from: "#", pred: Bot},
+	{from: "{", sym: LPar},
+	{from: "}", sym: RPar},
+	{from: "[", sym: LPar},
+	{from: "]", sym: RPar},
+}
 
-func convertNotation(sA string) (sB string, ok bool) {
+// The whitespace matched by \s in RE2.
+const whiteSpace = "\t\n\f\r "
+
+func convertNotationAt(sA string) (syms []Symbol, offs []int, end int, offB int) {
 	var (
-		convs []convStruct
-		conv  convStruct
-		rB    rune
-		chrs  string
+		rsA, rsF  []rune
+		offsA     []int
+		r         rune
+		dex, lenA int
+		conv      convStruct
+		sym       Symbol
+		symsF     []Symbol
+		offsF     []int
+		found     bool
 	)
 
-	sB = rexWS.ReplaceAllString(sA, "")
-
-	convs = []convStruct{
-		// Three-character transformations:
-		{from: "<->", sym: Iff},
-		// Two-character transformations:
-		{from: "->", sym: To},
-		{from: "\\/", sym: Vee},
-		{from: "/\\", sym: Wedge},
-		{from: "[]", sym: Box},
-		{from: "<>", sym: Diamond},
-		// One-character transformations:
-		{from: "~", sym: Neg},
-		{from: "$", sym: Exists},
-		{from: "@", sym: ForAll},
-		{from: "^", pred: Top},
-		{from: "#", pred: Bot},
-		{from: "{", sym: LPar},
-		{from: "}", sym: RPar},
-		{from: "[", sym: LPar},
-		{from: "]", sym: RPar},
+	// Strip the whitespace, remembering where each remaining rune came from.
+	for dex, r = range []rune(sA) {
+		if !strings.ContainsRune(whiteSpace, r) {
+			rsA = append(rsA, r)
+
+			offsA = append(offsA, dex)
+		}
 	}
 
-	for _, conv = range convs {
-		if conv.sym != 0 {
-			sB = strings.ReplaceAll(sB, conv.from, string(conv.sym))
+	end = utf8.RuneCountInString(sA)
+
+	lenA = len(rsA)
+
+	for dex = 0; dex < lenA; {
+		found = false
+
+		for _, conv = range convs {
+			if rsF = []rune(conv.from); !slices.Equal(rsA[dex:min(dex+len(rsF), lenA)], rsF) {
+				continue
+			}
+
+			if conv.sym != 0 {
+				syms = append(syms, conv.sym)
+			} else {
+				syms = append(syms, Symbol(conv.pred))
+			}
+
+			offs = append(offs, offsA[dex])
+
+			dex, found = dex+len(rsF), true
+
+			break
 		}
 
-		if conv.pred != 0 {
-			sB = strings.ReplaceAll(sB, conv.from, string(conv.pred))
+		if !found {
+			syms = append(syms, Symbol(rsA[dex]))
+
+			offs = append(offs, offsA[dex])
+
+			dex += 1
 		}
 	}
 
-	chrs = string(PredConsts) + string(PredVars) +
-		string(ArgConsts) + string(ArgVars) +
-		string(UnaryOps) + string(BinaryOps) + string(Quantifiers) +
-		string(LPar) + string(RPar) +
-		string(Top) + string(Bot) + string(Equals)
+	if symsF, offsF, offB = foldIndexedSymbols(syms, offs); offB != -1 {
+		return
+	}
+
+	syms, offs = symsF, offsF
 
-	ok = true
+	for dex, sym = range syms {
+		if !isNotationSymbol(sym) {
+			offB = offs[dex]
 
-	for _, rB = range sB {
-		if ok = strings.ContainsRune(chrs, rB); !ok {
 			break
 		}
 	}
@@ -84,20 +119,79 @@ func convertNotation(sA string) (sB string, ok bool) {
 	return
 }
 
-func newParser(s string) (prs *parser, ok bool) {
+func convertNotation(sA string) (sB string, ok bool) {
 	var (
-		sym         Symbol
-		depth, lenS int
+		syms []Symbol
+		offB int
+	)
+
+	syms, _, _, offB = convertNotationAt(sA)
+
+	sB, ok = string(syms), offB == -1
+
+	return
+}
+
+func isPredSymbol(sym Symbol) (is bool) {
+	is = IsPredConst(Predicate(sym)) || IsPredVar(Predicate(sym))
+
+	return
+}
+
+func isArgSymbol(sym Symbol) (is bool) {
+	is = IsArgConst(Argument(sym)) || IsArgVar(Argument(sym)) || IsTermMeta(Argument(sym))
+
+	return
+}
+
+func isMetaSymbol(sym Symbol) (is bool) {
+	is = IsFormulaMeta(Predicate(sym)) || IsTermMeta(Argument(sym))
+
+	return
+}
+
+func isNotationSymbol(sym Symbol) (is bool) {
+	is = isPredSymbol(sym) || isArgSymbol(sym) || IsFormulaMeta(Predicate(sym)) ||
+		slices.Contains(UnaryOps, sym) || slices.Contains(BinaryOps, sym) || slices.Contains(Quantifiers, sym) ||
+		sym == LPar || sym == RPar || sym == Comma ||
+		sym == Symbol(Top) || sym == Symbol(Bot) || sym == Symbol(Equals)
+
+	return
+}
+
+func offsetOf(prs *parser, dex int) (off int) {
+	if dex < len(prs.offs) {
+		off = prs.offs[dex]
+	} else {
+		off = prs.end
+	}
+
+	return
+}
+
+func newParserAt(syms []Symbol, offs []int, end int, opts *ParseOptions) (prs *parser, perr *ParseError) {
+	var (
+		sym              Symbol
+		dex, depth, lenS int
 	)
 
 	prs = &parser{
-		s:    s,
-		syms: []Symbol(s),
+		s:    string(syms),
+		syms: syms,
 		deps: []int{},
+		offs: offs,
+		end:  end,
+		opts: opts,
 		wffs: []*WffTree{},
 	}
 
-	for _, sym = range prs.syms {
+	if lenS = len(prs.syms); lenS == 0 {
+		perr = newParseError(StepNewParser, ExpectOperand, end)
+
+		return
+	}
+
+	for dex, sym = range prs.syms {
 		switch sym {
 		case LPar:
 			depth += 1
@@ -105,40 +199,254 @@ func newParser(s string) (prs *parser, ok bool) {
 			depth -= 1
 		}
 
+		if depth < 0 && perr == nil {
+			perr = newParseError(StepNewParser, ExpectLPar, prs.offs[dex])
+		}
+
 		prs.deps = append(prs.deps, depth)
 	}
 
-	lenS = len(prs.syms)
-
-	ok = 0 < lenS &&
-		!slices.Contains(prs.deps, -1) &&
-		prs.deps[lenS-1] == 0
+	if perr == nil && depth != 0 {
+		perr = newParseError(StepNewParser, ExpectRPar, end)
+	}
 
-	if ok &&
+	if perr == nil &&
 		prs.syms[0] == LPar &&
 		!slices.Contains(prs.deps[1:lenS-1], 0) &&
 		prs.syms[lenS-1] == RPar {
-		s = string(prs.syms[1 : lenS-1])
+		prs, perr = newParserAt(prs.syms[1:lenS-1], prs.offs[1:lenS-1], prs.offs[lenS-1], opts)
+	}
+
+	return
+}
 
-		prs, ok = newParser(s)
+func newParser(s string) (prs *parser, ok bool) {
+	var (
+		syms []Symbol
+		offs []int
+		dex  int
+		perr *ParseError
+	)
+
+	syms = []Symbol(s)
+
+	offs = make([]int, len(syms))
+
+	for dex = range offs {
+		offs[dex] = dex
+	}
+
+	prs, perr = newParserAt(syms, offs, len(syms), NewParseOptions(Strict))
+
+	ok = perr == nil
+
+	return
+}
+
+func subParser(prs *parser, from, to int) (prsS *parser, perr *ParseError) {
+	prsS, perr = newParserAt(prs.syms[from:to], prs.offs[from:to], offsetOf(prs, to), prs.opts)
+
+	return
+}
+
+// scanTerm reads the term that begins at syms[dex], if there is one,
+// and returns the index just past it.
+func scanTerm(syms []Symbol, dex int) (t *Term, next int, ok bool) {
+	var (
+		lenS   int
+		letter rune
+		idx    uint
+		sub    *Term
+		subs   []*Term
+	)
+
+	if lenS = len(syms); lenS <= dex || !isArgSymbol(syms[dex]) {
+		return
+	}
+
+	if next = dex + 1; lenS <= next || syms[next] != LPar {
+		t, ok = NewArgTerm(Argument(syms[dex])), true
+
+		return
+	}
+
+	// Only the letters of argument constants name functions.
+	if letter, idx = SplitArgument(Argument(syms[dex])); !IsArgConst(Argument(syms[dex])) || MaxFuncIndex < idx {
+		return
+	}
+
+	for {
+		if sub, next, ok = scanTerm(syms, next+1); !ok || lenS <= next {
+			ok = false
+
+			return
+		}
+
+		subs = append(subs, sub)
+
+		if syms[next] == RPar {
+			break
+		}
+
+		if syms[next] != Comma {
+			ok = false
+
+			return
+		}
+	}
+
+	if ok = uint(len(subs)) < MaxArity+1; ok {
+		t, next = NewFuncTerm(NewFunction(letter, idx, uint(len(subs))), subs...), next+1
+	}
+
+	return
+}
+
+// scanTerms reads as many terms as follow one another from syms[dex] onward.
+func scanTerms(syms []Symbol, dex int) (ts []*Term, next int) {
+	var (
+		t    *Term
+		dexT int
+		ok   bool
+	)
+
+	for next = dex; ; next = dexT {
+		if t, dexT, ok = scanTerm(syms, next); !ok {
+			break
+		}
+
+		ts = append(ts, t)
+	}
+
+	return
+}
+
+func scanIdentity(syms []Symbol) (tL, tR *Term, next int, ok bool) {
+	var (
+		dex int
+	)
+
+	if tL, dex, ok = scanTerm(syms, 0); ok {
+		if ok = dex < len(syms) && syms[dex] == Symbol(Equals); ok {
+			tR, next, ok = scanTerm(syms, dex+1)
+		}
 	}
 
 	return
 }
 
-func findMainOperator(prs *parser) (mop Symbol, dexM int, ok bool) {
+func isTorFParser(prs *parser) (is bool) {
+	is = len(prs.syms) == 1 && (prs.syms[0] == Symbol(Top) || prs.syms[0] == Symbol(Bot))
+
+	return
+}
+
+func isIdenParser(prs *parser) (is bool) {
+	var (
+		next int
+	)
+
+	_, _, next, is = scanIdentity(prs.syms)
+
+	is = is && next == len(prs.syms)
+
+	return
+}
+
+func isBaseParser(prs *parser) (is bool) {
+	var (
+		next int
+	)
+
+	if is = isPredSymbol(prs.syms[0]); is {
+		_, next = scanTerms(prs.syms, 1)
+
+		is = next == len(prs.syms)
+	}
+
+	return
+}
+
+func isMetaParser(prs *parser) (is bool) {
+	is = len(prs.syms) == 1 && IsFormulaMeta(Predicate(prs.syms[0]))
+
+	return
+}
+
+func isAtomicParser(prs *parser) (is bool) {
+	is = isTorFParser(prs) || isMetaParser(prs) || isIdenParser(prs) || isBaseParser(prs)
+
+	return
+}
+
+func isBoundVarSymbol(sym Symbol) (is bool) {
+	is = IsPredVar(Predicate(sym)) || IsArgVar(Argument(sym))
+
+	return
+}
+
+func atomicPrefixLength(prs *parser) (lenP int) {
+	var (
+		ok bool
+	)
+
+	switch {
+	case prs.syms[0] == Symbol(Top) || prs.syms[0] == Symbol(Bot) || IsFormulaMeta(Predicate(prs.syms[0])):
+		lenP = 1
+	case isPredSymbol(prs.syms[0]):
+		_, lenP = scanTerms(prs.syms, 1)
+	default:
+		if _, _, lenP, ok = scanIdentity(prs.syms); !ok {
+			lenP = 0
+		}
+	}
+
+	return
+}
+
+func diagnoseOperand(prs *parser) (perr *ParseError) {
+	var (
+		lenS, lenP int
+	)
+
+	lenS = len(prs.syms)
+
+	switch {
+	case slices.Contains(Quantifiers, prs.syms[0]):
+		if lenS < 2 || !isBoundVarSymbol(prs.syms[1]) {
+			perr = newParseError(StepFindMainOperator, ExpectBoundVar, offsetOf(prs, 1))
+		} else {
+			perr = newParseError(StepFindMainOperator, ExpectOperand, offsetOf(prs, 2))
+		}
+	case slices.Contains(UnaryOps, prs.syms[0]):
+		perr = newParseError(StepFindMainOperator, ExpectOperand, offsetOf(prs, 1))
+	case slices.Contains(BinaryOps, prs.syms[0]):
+		perr = newParseError(StepFindMainOperator, ExpectOperand, offsetOf(prs, 0))
+	case slices.Contains(BinaryOps, prs.syms[lenS-1]):
+		perr = newParseError(StepFindMainOperator, ExpectOperand, prs.end)
+	default:
+		if lenP = atomicPrefixLength(prs); lenP == 0 {
+			perr = newParseError(StepFindMainOperator, ExpectOperand, offsetOf(prs, 0))
+		} else {
+			perr = newParseError(StepFindMainOperator, ExpectOperator, offsetOf(prs, lenP))
+		}
+	}
+
+	return
+}
+
+func findMainOperator(prs *parser) (mop Symbol, dexM int, perr *ParseError) {
 	var (
 		dexesB           []int
 		sym, unop, binop Symbol
 		dexS, lenS, lenB int
 	)
 
-	// Check for a minimally viable lead unary operator at depth 0.
+	// Check for a minimally viable lead unary operator or quantifier at depth 0.
 	if lenS = len(prs.syms); 1 < lenS && prs.deps[0] == 0 && slices.Contains(UnaryOps, prs.syms[0]) {
 		unop = prs.syms[0]
 	} else if 2 < lenS && prs.deps[0] == 0 && prs.deps[1] == 0 &&
-		(slices.Contains(PredVars, Predicate(prs.syms[1])) ||
-			slices.Contains(ArgVars, Argument(prs.syms[1]))) {
+		slices.Contains(Quantifiers, prs.syms[0]) && isBoundVarSymbol(prs.syms[1]) {
 		unop = prs.syms[0]
 	}
 
@@ -156,15 +464,25 @@ func findMainOperator(prs *parser) (mop Symbol, dexM int, ok bool) {
 				lenB += 1
 			}
 
-			if lenB == 2 {
+			// Strict parsing needs no more than two binary operators to reject a formula.
+			if lenB == 2 && prs.opts.Mode == Strict {
 				break
 			}
 		}
 
-		if lenB == 1 {
+		switch {
+		case lenB == 1:
 			dexS = dexesB[0]
 
 			binop = prs.syms[dexS]
+		case 1 < lenB && prs.opts.Mode == Precedence:
+			if dexS, perr = selectMainBinary(prs, dexesB); perr != nil {
+				mop, dexM = NoSymbol, -1
+
+				return
+			}
+
+			binop, lenB = prs.syms[dexS], 1
 		}
 	}
 
@@ -172,60 +490,58 @@ func findMainOperator(prs *parser) (mop Symbol, dexM int, ok bool) {
 	// or establish that the formula is ill-formed.
 	switch {
 	case 1 < lenB: // There are two binary operators at depth 0.
-		mop, dexM, ok = NoSymbol, -1, false
+		mop, dexM = NoSymbol, -1
+
+		perr = newParseError(StepFindMainOperator, ExpectGrouping, prs.offs[dexesB[1]])
 	case binop != 0: // There is a binary operator at depth 0.
-		mop, dexM, ok = binop, dexS, true // dexS is still dexesB[0].
+		mop, dexM = binop, dexS // dexS is still dexesB[0], or the loosest binding operator.
 	case unop != 0: // There is a unary operator.
-		mop, dexM, ok = unop, 0, true
+		mop, dexM = unop, 0
 	default: // There is no unary or binary operator at depth 0.
 		mop, dexM = NoSymbol, -1
 
-		ok = rexBase.MatchString(prs.s) ||
-			rexTorF.MatchString(prs.s) ||
-			rexIden.MatchString(prs.s)
+		if !isAtomicParser(prs) {
+			perr = diagnoseOperand(prs)
+		}
 	}
 
 	return
 }
 
-func cutParser(prs *parser) (mop Symbol, pv Predicate, av Argument, prsL, prsR *parser, ok bool) {
+func cutParser(prs *parser) (mop Symbol, pv Predicate, av Argument, prsL, prsR *parser, perr *ParseError) {
 	var (
-		dex      int
-		okL, okR bool
+		dex, lenS int
+		perrR     *ParseError
 	)
 
-	if rexBase.MatchString(prs.s) || rexTorF.MatchString(prs.s) || rexIden.MatchString(prs.s) {
+	lenS = len(prs.syms)
+
+	if isAtomicParser(prs) {
 		// There is no cutting an atomic formula.
-		mop, prsL, prsR, ok = NoSymbol, prs, nil, true
-	} else if mop, dex, ok = findMainOperator(prs); !ok {
-		mop, prsL, prsR, ok = NoSymbol, nil, nil, false
-	} else {
+		mop, prsL, prsR = NoSymbol, prs, nil
+	} else if mop, dex, perr = findMainOperator(prs); perr == nil {
 		switch mop {
 		case Neg, Box, Diamond:
-			prsL, okL = newParser(string(prs.syms[1:]))
-
-			okR = true
+			prsL, perr = subParser(prs, 1, lenS)
 		case Wedge, Vee, To, Iff:
-			prsL, okL = newParser(string(prs.syms[:dex]))
+			prsL, perr = subParser(prs, 0, dex)
 
-			prsR, okR = newParser(string(prs.syms[dex+1:]))
+			if prsR, perrR = subParser(prs, dex+1, lenS); perr == nil {
+				perr = perrR
+			}
 		case Exists, ForAll:
-			if slices.Contains(PredVars, Predicate(prs.syms[1])) {
+			if IsPredVar(Predicate(prs.syms[1])) {
 				pv = Predicate(prs.syms[1])
 			}
 
-			if slices.Contains(ArgVars, Argument(prs.syms[1])) {
+			if IsArgVar(Argument(prs.syms[1])) {
 				av = Argument(prs.syms[1])
 			}
 
-			prsL, okL = newParser(string(prs.syms[2:]))
-
-			okR = true
+			prsL, perr = subParser(prs, 2, lenS)
 		default:
 			panic("Invalid main operator.")
 		}
-
-		ok = okL && okR
 	}
 
 	return
@@ -233,41 +549,28 @@ func cutParser(prs *parser) (mop Symbol, pv Predicate, av Argument, prsL, prsR *
 
 func parseAtomicFmla(prs *parser) (wff *WffTree) {
 	var (
-		pred       Predicate
-		args       []Argument
-		argL, argR Argument
-		lenS, dex  int
+		pred   Predicate
+		ts     []*Term
+		tL, tR *Term
 	)
 
 	switch {
-	case rexTorF.MatchString(prs.s): // Top or bottom.
+	case isTorFParser(prs): // Top or bottom.
 		pred = Predicate(prs.syms[0])
 
 		wff = NewAtomicWff(pred)
-	case rexIden.MatchString(prs.s): // Identity predicate.
-		if lenS = len(prs.syms); lenS != 3 {
-			panic("An identity predicate must have exactly three characters.")
-		}
-
-		pred = Predicate(prs.syms[1])
-
-		argL, argR = Argument(prs.syms[0]), Argument(prs.syms[2])
+	case isMetaParser(prs): // Schema letter for a formula.
+		wff = newAtomic(Predicate(prs.syms[0]), "")
+	case isIdenParser(prs): // Identity predicate.
+		tL, tR, _, _ = scanIdentity(prs.syms)
 
-		wff = NewAtomicWff(pred, argL, argR)
-	case rexBase.MatchString(prs.s): // Base atomic predicate.
+		wff = NewAtomicTermWff(Equals, tL, tR)
+	case isBaseParser(prs): // Base atomic predicate.
 		pred = Predicate(prs.syms[0])
 
-		if lenS = len(prs.syms); 1 < lenS {
-			args = make([]Argument, lenS-1)
-
-			for dex = 1; dex < lenS; dex += 1 {
-				argR = Argument(prs.syms[dex])
-
-				args[dex-1] = argR
-			}
-		}
+		ts, _ = scanTerms(prs.syms, 1)
 
-		wff = NewAtomicWff(pred, args...)
+		wff = NewAtomicTermWff(pred, ts...)
 	default:
 		panic("An approved cut led to an illegal atomic formula.")
 	}
@@ -291,67 +594,142 @@ func isClosedWff(wff *WffTree) (is bool) {
 	return
 }
 
-func parseFullFmla(s string) (fmla *WffTree, ok bool) {
+func parseFullFmla(prs *parser) (fmla *WffTree, perr *ParseError) {
 	var (
-		prs        *parser
 		mop        Symbol
 		pv         Predicate
 		av         Argument
 		prsL, prsR *parser
 		subL, subR *WffTree
-		okL, okR   bool
 	)
 
-	if s, ok = convertNotation(s); ok {
-		if prs, ok = newParser(s); ok {
-			if mop, pv, av, prsL, prsR, ok = cutParser(prs); ok {
-				switch mop {
-				case NoSymbol:
-					fmla = parseAtomicFmla(prs)
-
-					ok = fmla != nil
-				case Neg, Box, Diamond:
-					subL, okL = parseFullFmla(prsL.s)
-
-					if ok = okL; ok {
-						fmla = NewCompositeWff(mop, subL, nil, 0, 0)
-					}
-				case Wedge, Vee, To, Iff:
-					subL, okL = parseFullFmla(prsL.s)
-
-					subR, okR = parseFullFmla(prsR.s)
-
-					if ok = okL && okR; ok {
-						fmla = NewCompositeWff(mop, subL, subR, 0, 0)
-					}
-				case Exists, ForAll:
-					subL, okL = parseFullFmla(prsL.s)
-
-					if ok = okL; ok {
-						fmla = NewCompositeWff(mop, subL, nil, pv, av)
-					}
-				default:
-					panic("Invalid main operator.")
+	if mop, pv, av, prsL, prsR, perr = cutParser(prs); perr == nil {
+		switch mop {
+		case NoSymbol:
+			fmla = parseAtomicFmla(prs)
+		case Neg, Box, Diamond:
+			if subL, perr = parseFullFmla(prsL); perr == nil {
+				fmla = NewCompositeWff(mop, subL, nil, 0, 0)
+			}
+		case Wedge, Vee, To, Iff:
+			if subL, perr = parseFullFmla(prsL); perr == nil {
+				if subR, perr = parseFullFmla(prsR); perr == nil {
+					fmla = NewCompositeWff(mop, subL, subR, 0, 0)
 				}
 			}
+		case Exists, ForAll:
+			if subL, perr = parseFullFmla(prsL); perr == nil {
+				fmla = NewCompositeWff(mop, subL, nil, pv, av)
+			}
+		default:
+			panic("Invalid main operator.")
 		}
 	}
 
 	return
 }
 
-func ParseStringToWff(s string) (wff *WffTree, ok bool) {
+// locateFreeVariable finds the offset of the first variable in prs
+// that is bound neither by bvs nor by a quantifier within prs.
+// It assumes that prs has already been parsed successfully.
+func locateFreeVariable(prs *parser, bvs []Symbol) (off int, found bool) {
 	var (
-		fmla *WffTree
+		mop        Symbol
+		pv         Predicate
+		av         Argument
+		prsL, prsR *parser
+		dex        int
+		sym        Symbol
 	)
 
-	fmla, ok = parseFullFmla(s)
+	mop, pv, av, prsL, prsR, _ = cutParser(prs)
 
-	if ok = ok && isClosedWff(fmla); ok {
-		wff = fmla
+	switch mop {
+	case NoSymbol:
+		for dex, sym = range prs.syms {
+			if isBoundVarSymbol(sym) && !slices.Contains(bvs, sym) {
+				off, found = prs.offs[dex], true
+
+				break
+			}
+		}
+	case Neg, Box, Diamond:
+		off, found = locateFreeVariable(prsL, bvs)
+	case Wedge, Vee, To, Iff:
+		if off, found = locateFreeVariable(prsL, bvs); !found {
+			off, found = locateFreeVariable(prsR, bvs)
+		}
+	case Exists, ForAll:
+		bvs = append(slices.Clone(bvs), Symbol(pv)+Symbol(av)) // Only one of pv and av is set.
+
+		off, found = locateFreeVariable(prsL, bvs)
+	}
+
+	return
+}
+
+func ParseWffWith(s string, opts *ParseOptions) (wff *WffTree, err error) {
+	var (
+		syms      []Symbol
+		offs      []int
+		end, offB int
+		prs       *parser
+		fmla      *WffTree
+		perr      *ParseError
+	)
+
+	if syms, offs, end, offB = convertNotationAt(s); offB == -1 && !opts.Schemata {
+		offB = slices.IndexFunc(syms, isMetaSymbol)
+
+		if offB != -1 {
+			offB = offs[offB]
+		}
+	}
+
+	if offB != -1 {
+		perr = newParseError(StepConvertNotation, ExpectSymbol, offB)
+	} else if prs, perr = newParserAt(syms, offs, end, opts); perr == nil {
+		if fmla, perr = parseFullFmla(prs); perr == nil && !isClosedWff(fmla) {
+			perr = newParseError(StepIsClosedWff, ExpectClosure, 0)
+
+			perr.Offset, _ = locateFreeVariable(prs, nil)
+
+			perr.FreePVs, perr.FreeAVs = GetFreeVariables(fmla)
+		}
+	}
+
+	if perr != nil {
+		perr.Input = s
+
+		err = perr
 	} else {
-		wff = nil
+		wff = fmla
 	}
 
 	return
 }
+
+func ParseWff(s string) (wff *WffTree, err error) {
+	wff, err = ParseWffWith(s, strictOptions)
+
+	return
+}
+
+// ParseSchema parses a formula in which schema letters may stand for formulae and terms, as in φ→(ψ→φ) or ∀xFx→Fτ.
+func ParseSchema(s string) (wff *WffTree, err error) {
+	wff, err = ParseWffWith(s, schemaOptions)
+
+	return
+}
+
+func ParseStringToWff(s string) (wff *WffTree, ok bool) {
+	var (
+		err error
+	)
+
+	wff, err = ParseWff(s)
+
+	ok = err == nil
+
+	return
+}
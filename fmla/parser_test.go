@@ -239,6 +239,35 @@ func TestParseStringToWff(t *testing.T) {
 		{"∀X∀x∀y(Xxy↔Xyx)", true, ForAll},
 		{"∀X◇(¬X→A)", true, ForAll},
 
+		// Well-formed indexed formulae:
+		{"Fa₁", true, NoSymbol},
+		{"F1a12", true, NoSymbol},
+		{"a₂₀=b", true, NoSymbol},
+		{"∀x₁∃X₃X₃x₁t₁", true, ForAll},
+		{"A₁→A₂", true, To},
+
+		// Well-formed formulae with function terms:
+		{"Ff(a)", true, NoSymbol},
+		{"Rf(a,b)c", true, NoSymbol},
+		{"s(s(a))=b", true, NoSymbol},
+		{"∀x∀y(g(x,y)=g(y,x))", true, ForAll},
+		{"∀xFf₁(x)", true, ForAll},
+
+		// Ill-formed formulae with function terms:
+		{"Ff()", false, NoSymbol},
+		{"Ff(a", false, NoSymbol},
+		{"Ff(a,)", false, NoSymbol},
+		{"Fx(a)", false, NoSymbol},
+		{"f(a)", false, NoSymbol},
+		{"Ff(a,b,c,d,e,f,g,h)", false, NoSymbol},
+
+		// Ill-formed indexed formulae:
+		{"Fa0", false, NoSymbol},
+		{"Fa01", false, NoSymbol},
+		{"Fa1024", false, NoSymbol},
+		{"F=1", false, NoSymbol},
+		{"∀x₁Fx₂", false, ForAll},
+
 		// Ill-formed mixed formulae:
 		{"A¬B∨C", false, Vee},
 		{"(A→B)∨(C□→D)", false, Vee},
@@ -298,6 +327,11 @@ func TestRoundTrip(t *testing.T) {
 		"□∀xFx",
 		"∀X◇(¬X→A)",
 		"∀X∀x((Xx→A)∧(A→Xx))",
+		"Fa₁b₂₀t₁₀₂₃",
+		"∀x₁∃X₃X₃x₁t₁",
+		"a1=a2",
+		"Rf(a,g(b))c",
+		"∀x(s(x)=s(s(x))→Fs(x))",
 	}
 
 	for _, sA = range ss {
@@ -322,3 +356,198 @@ func TestRoundTrip(t *testing.T) {
 		}
 	}
 }
+
+func TestParseWff(t *testing.T) {
+	type testCase struct {
+		s    string
+		step ParseStep
+		exp  Expectation
+		off  int
+	}
+
+	var (
+		tcs  []testCase
+		tc   testCase
+		err  error
+		perr *ParseError
+		ok   bool
+	)
+
+	tcs = []testCase{
+		// Unrecognized symbols:
+		{"A&B", StepConvertNotation, ExpectSymbol, 1},
+		{"A => B", StepConvertNotation, ExpectSymbol, 3},
+
+		// Unbalanced parentheses:
+		{"", StepNewParser, ExpectOperand, 0},
+		{"(A", StepNewParser, ExpectRPar, 2},
+		{"A)", StepNewParser, ExpectLPar, 1},
+		{"¬()", StepNewParser, ExpectOperand, 2},
+
+		// Missing or ambiguous operators and operands:
+		{"A∧B∧C", StepFindMainOperator, ExpectGrouping, 3},
+		{"A -> B -> C", StepFindMainOperator, ExpectGrouping, 7},
+		{"A∧", StepFindMainOperator, ExpectOperand, 2},
+		{"A -> ~", StepFindMainOperator, ExpectOperand, 6},
+		{"∀Fx", StepFindMainOperator, ExpectBoundVar, 1},
+		{"AB", StepFindMainOperator, ExpectOperator, 1},
+		{"AxB", StepFindMainOperator, ExpectOperator, 2},
+
+		// Malformed indices:
+		{"Fa01", StepConvertNotation, ExpectSymbol, 2},
+		{"Fa1024", StepConvertNotation, ExpectSymbol, 5},
+		{"F(a)1", StepConvertNotation, ExpectSymbol, 4},
+
+		// Free variables:
+		{"∀x(x=y)", StepIsClosedWff, ExpectClosure, 5},
+		{"∃x(Fx) ∧ Gx", StepIsClosedWff, ExpectClosure, 10},
+	}
+
+	for _, tc = range tcs {
+		if _, err = ParseWff(tc.s); err == nil {
+			t.Errorf("\nFAILED: Expected an error from %q, got none.", tc.s)
+
+			break
+		}
+
+		if perr, ok = err.(*ParseError); !ok {
+			t.Errorf("\nFAILED: Expected a *ParseError from %q, got %T.", tc.s, err)
+
+			break
+		}
+
+		if perr.Step != tc.step || perr.Expected != tc.exp || perr.Offset != tc.off {
+			t.Errorf("\nFAILED: Expected %s, %s, %d from %q, got %s, %s, %d.",
+				tc.step, tc.exp, tc.off, tc.s, perr.Step, perr.Expected, perr.Offset)
+
+			break
+		}
+
+		t.Logf("\nPASSED: %v", err)
+	}
+
+	if _, err = ParseWff("∀x(Fx ∧ Gyx)"); err != nil {
+		if perr, ok = err.(*ParseError); ok && len(perr.FreeAVs) == 1 && perr.FreeAVs[0] == 'y' {
+			t.Logf("\nPASSED: Listed the free variables of %q.", perr.Input)
+		} else {
+			t.Errorf("\nFAILED: Expected free variable 'y' from %q, got %v.", "∀x(Fx ∧ Gyx)", err)
+		}
+	} else {
+		t.Errorf("\nFAILED: Expected an error, got none.")
+	}
+}
+
+func TestParseWffWithPrecedence(t *testing.T) {
+	type testCase struct {
+		s, sS string
+		exp   bool
+	}
+
+	var (
+		opts       *ParseOptions
+		tcs        []testCase
+		tc         testCase
+		wffA, wffB *WffTree
+		err        error
+	)
+
+	opts = NewParseOptions(Precedence)
+
+	tcs = []testCase{
+		// Operators of different precedence:
+		{"A∧B∨C", "(A∧B)∨C", true},
+		{"A∨B∧C", "A∨(B∧C)", true},
+		{"¬A∨B→C", "(¬A∨B)→C", true},
+		{"A→B↔C∧D", "(A→B)↔(C∧D)", true},
+		{"□A∧∀xFx", "□A∧∀xFx", true},
+		{"¬(A∨B)∧C", "¬(A∨B)∧C", true},
+
+		// Operators of the same precedence:
+		{"A∧B∧C", "(A∧B)∧C", true},
+		{"A∨B∨C∨D", "((A∨B)∨C)∨D", true},
+		{"A→B→C", "A→(B→C)", true},
+		{"A↔B↔C", "", false}, // Iff is not associative by default.
+
+		// Ill-formed formulae remain ill-formed:
+		{"A∧∨B", "", false},
+		{"∀xFx∧Gx", "", false},
+	}
+
+	for _, tc = range tcs {
+		if wffA, err = ParseWffWith(tc.s, opts); (err == nil) != tc.exp {
+			t.Errorf("\nFAILED: Expected %t from %q, got %v.", tc.exp, tc.s, err)
+
+			break
+		}
+
+		if !tc.exp {
+			t.Logf("\nPASSED: Detected ill-formed parse: %q.", tc.s)
+
+			continue
+		}
+
+		if wffB, err = ParseWff(tc.sS); err != nil || !IsIdentical(wffA, wffB) {
+			t.Errorf("\nFAILED: Expected %q to parse as %q.", tc.s, tc.sS)
+
+			break
+		}
+
+		t.Logf("\nPASSED: Parsed %q as %q.", tc.s, tc.sS)
+	}
+}
+
+func TestMinimalRoundTrip(t *testing.T) {
+	var (
+		optss      []*ParseOptions
+		opts       *ParseOptions
+		ss         []string
+		sA, sB     string
+		wffA, wffB *WffTree
+		err        error
+	)
+
+	optss = []*ParseOptions{
+		NewParseOptions(Strict),
+		NewParseOptions(Precedence),
+		{Mode: Precedence, Assoc: map[Symbol]Assoc{Wedge: AssocRight, Vee: AssocNone, To: AssocLeft, Iff: AssocLeft}},
+	}
+
+	ss = []string{
+		"(A∧B)∧C",
+		"A∧(B∧C)",
+		"(A∨B)∨(C∨D)",
+		"(A→B)→C",
+		"A→(B→C)",
+		"(A↔B)↔C",
+		"A↔(B↔C)",
+		"¬(A∧B)∨(C→□(D∧E))",
+		"∀x((Fx∧Gx)→(Hx∨(Ix∧Jx)))",
+		"(A→B)∧(B→A)",
+	}
+
+	for _, opts = range optss {
+		for _, sA = range ss {
+			if wffA, err = ParseWff(sA); err != nil {
+				t.Errorf("\nFAILED: Failed to parse %q: %v.", sA, err)
+
+				return
+			}
+
+			sB = GetMinimalWffString(wffA, opts)
+
+			if wffB, err = ParseWffWith(sB, opts); err != nil {
+				t.Errorf("\nFAILED: Failed to reparse %q as %q: %v.", sA, sB, err)
+
+				return
+			}
+
+			if !IsIdentical(wffA, wffB) {
+				t.Errorf("\nFAILED: %q and %q are not identical by their hashes.", sA, sB)
+
+				return
+			}
+
+			t.Logf("\nPASSED: Printed %q as %q.", sA, sB)
+		}
+	}
+}
@@ -0,0 +1,243 @@
+package fmla
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// A Position addresses a subformula by the steps down to it from the root, as in term rewriting:
+// 1 to the sole or left subformula and 2 to the right one. The root is at the empty Position.
+type Position []uint8
+
+func (pos Position) String() (s string) {
+	var (
+		ss   []string
+		step uint8
+	)
+
+	if len(pos) == 0 {
+		s = "ε"
+
+		return
+	}
+
+	for _, step = range pos {
+		ss = append(ss, strconv.Itoa(int(step)))
+	}
+
+	s = strings.Join(ss, ".")
+
+	return
+}
+
+// A Polarity says whether a subformula occurs positively, negatively or both ways in a formula,
+// that is, whether strengthening it strengthens the formula, weakens it, or neither for certain.
+type Polarity uint8
+
+const (
+	Positive Polarity = iota + 1
+	Negative
+	Both
+)
+
+func (pol Polarity) String() (s string) {
+	switch pol {
+	case Positive:
+		s = "+"
+	case Negative:
+		s = "-"
+	case Both:
+		s = "±"
+	default:
+		s = "?"
+	}
+
+	return
+}
+
+func (pol Polarity) flip() (polF Polarity) {
+	switch pol {
+	case Positive:
+		polF = Negative
+	case Negative:
+		polF = Positive
+	default:
+		polF = pol
+	}
+
+	return
+}
+
+// Position returns the Position of the focus in the root formula.
+func (z Zipper) Position() (pos Position) {
+	var (
+		fr zipperFrame
+	)
+
+	pos = Position{}
+
+	for _, fr = range z.path {
+		if fr.right {
+			pos = append(pos, 2)
+		} else {
+			pos = append(pos, 1)
+		}
+	}
+
+	return
+}
+
+// ZipperAt returns a Zipper focused on the subformula at a Position, failing if there is none.
+func ZipperAt(wff *WffTree, pos Position) (z Zipper, ok bool) {
+	var (
+		step uint8
+	)
+
+	z, ok = NewZipper(wff), true
+
+	for _, step = range pos {
+		switch step {
+		case 1:
+			z, ok = z.Left()
+		case 2:
+			z, ok = z.Right()
+		default:
+			ok = false
+		}
+
+		if !ok {
+			return
+		}
+	}
+
+	return
+}
+
+// SubformulaAt returns the subformula of wff at a Position, failing if there is none.
+func SubformulaAt(wff *WffTree, pos Position) (sub *WffTree, ok bool) {
+	var (
+		z Zipper
+	)
+
+	if z, ok = ZipperAt(wff, pos); ok {
+		sub = z.Focus()
+	}
+
+	return
+}
+
+// ReplaceAt puts wffN in place of the subformula of wff at a Position, failing if there is none.
+func ReplaceAt(wff *WffTree, pos Position, wffN *WffTree) (wffR *WffTree, ok bool) {
+	var (
+		z Zipper
+	)
+
+	if z, ok = ZipperAt(wff, pos); ok {
+		wffR = z.Replace(wffN).Root()
+	}
+
+	return
+}
+
+// Positions returns the Position of every subformula of wff, in the order of AllSubformulae.
+func Positions(wff *WffTree) (poss []Position) {
+	var (
+		walk func(sub *WffTree, pos Position)
+	)
+
+	if wff == nil {
+		panic("Invalid WffTree")
+	}
+
+	walk = func(sub *WffTree, pos Position) {
+		poss = append(poss, pos)
+
+		if sub.subL != nil {
+			walk(sub.subL, append(slices.Clip(pos), 1))
+		}
+
+		if sub.subR != nil {
+			walk(sub.subR, append(slices.Clip(pos), 2))
+		}
+	}
+
+	walk(wff, Position{})
+
+	return
+}
+
+// PolarityAt returns the Polarity of the subformula of wff at a Position, failing if there is none.
+// Negation and the antecedent of a conditional flip the polarity, either side of a biconditional has both,
+// and every other operator, the modal ones and quantifiers included, keeps it.
+func PolarityAt(wff *WffTree, pos Position) (pol Polarity, ok bool) {
+	var (
+		step uint8
+	)
+
+	if wff == nil {
+		panic("Invalid WffTree")
+	}
+
+	pol = Positive
+
+	for _, step = range pos {
+		switch {
+		case step == 1 && wff.subL != nil:
+			switch wff.mop {
+			case Neg, To:
+				pol = pol.flip()
+			case Iff:
+				pol = Both
+			}
+
+			wff = wff.subL
+		case step == 2 && wff.subR != nil:
+			if wff.mop == Iff {
+				pol = Both
+			}
+
+			wff = wff.subR
+		default:
+			pol = 0
+
+			return
+		}
+	}
+
+	ok = true
+
+	return
+}
+
+// Differences returns the outermost Positions at which two formulae differ, in order,
+// so that each change from wffA to wffB can be pointed to. Subformulae of different shapes
+// are not compared inside, so the result is the root alone when the main operators differ.
+func Differences(wffA, wffB *WffTree) (poss []Position) {
+	var (
+		walk func(subA, subB *WffTree, pos Position)
+	)
+
+	if wffA == nil || wffB == nil {
+		panic("Invalid WffTree")
+	}
+
+	walk = func(subA, subB *WffTree, pos Position) {
+		switch {
+		case Equal(subA, subB):
+		case subA.kind == Atomic || subA.kind != subB.kind || subA.mop != subB.mop ||
+			subA.pVar != subB.pVar || subA.aVar != subB.aVar:
+			poss = append(poss, pos)
+		default:
+			walk(subA.subL, subB.subL, append(slices.Clip(pos), 1))
+
+			if subA.subR != nil {
+				walk(subA.subR, subB.subR, append(slices.Clip(pos), 2))
+			}
+		}
+	}
+
+	walk(wffA, wffB, Position{})
+
+	return
+}
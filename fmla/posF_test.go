@@ -0,0 +1,66 @@
+package fmla
+
+import (
+	"testing"
+)
+
+func TestPositions(t *testing.T) {
+	type testCase struct {
+		pos      Position
+		sub      string
+		pol      Polarity
+		replaced string
+	}
+
+	var (
+		tcs  []testCase
+		tc   testCase
+		wff  *WffTree
+		sub  *WffTree
+		wffR *WffTree
+		poss []Position
+		pol  Polarity
+		ok   bool
+		err  error
+	)
+
+	if wff, err = ParseWff("((P∧Q)→¬R)∨□(P↔Q)"); err != nil {
+		t.Fatalf("\nFAILED: %v", err)
+	}
+
+	tcs = []testCase{
+		{Position{}, "((P∧Q)→¬R)∨□(P↔Q)", Positive, "S"},
+		{Position{1, 1}, "P∧Q", Negative, "(S→¬R)∨□(P↔Q)"},
+		{Position{1, 1, 2}, "Q", Negative, "((P∧S)→¬R)∨□(P↔Q)"},
+		{Position{1, 2, 1}, "R", Negative, "((P∧Q)→¬S)∨□(P↔Q)"},
+		{Position{2, 1, 1}, "P", Both, "((P∧Q)→¬R)∨□(S↔Q)"},
+	}
+
+	for _, tc = range tcs {
+		if sub, ok = SubformulaAt(wff, tc.pos); !ok || GetWffString(sub) != tc.sub {
+			t.Errorf("\nFAILED: Expected %q at %s.", tc.sub, tc.pos)
+
+			continue
+		}
+
+		if pol, ok = PolarityAt(wff, tc.pos); !ok || pol != tc.pol {
+			t.Errorf("\nFAILED: Expected polarity %s at %s, got %s.", tc.pol, tc.pos, pol)
+		}
+
+		if wffR, ok = ReplaceAt(wff, tc.pos, NewAtomicWff('S')); !ok || GetWffString(wffR) != tc.replaced {
+			t.Errorf("\nFAILED: Expected %q after replacing at %s, got %q.", tc.replaced, tc.pos, GetWffString(wffR))
+		}
+
+		if 0 < len(tc.pos) && (len(Differences(wff, wffR)) != 1 || Differences(wff, wffR)[0].String() != tc.pos.String()) {
+			t.Errorf("\nFAILED: Expected the only difference at %s, got %v.", tc.pos, Differences(wff, wffR))
+		}
+	}
+
+	if _, ok = SubformulaAt(wff, Position{1, 2, 2}); ok {
+		t.Errorf("\nFAILED: Expected no subformula at 1.2.2.")
+	}
+
+	if poss = Positions(wff); len(poss) != len(AllSubformulae(wff)) || poss[4].String() != "1.1.2" {
+		t.Errorf("\nFAILED: Expected the positions in the order of AllSubformulae, got %v.", poss)
+	}
+}
@@ -0,0 +1,147 @@
+package fmla
+
+type ParseMode int
+
+const (
+	Strict     ParseMode = iota + 1 // Every binary subformula must be parenthesized.
+	Precedence                      // Binary subformulae are grouped by precedence and associativity.
+)
+
+type Assoc int
+
+const (
+	AssocNone  Assoc = iota // Chains of the operator must be parenthesized.
+	AssocLeft               // A∘B∘C groups as (A∘B)∘C.
+	AssocRight              // A∘B∘C groups as A∘(B∘C).
+)
+
+type ParseOptions struct {
+	Mode     ParseMode
+	Assoc    map[Symbol]Assoc // The associativity of each binary operator, used only in Precedence mode.
+	Schemata bool             // Whether schema letters may stand for formulae and terms.
+}
+
+// Unary operators and quantifiers bind more tightly than any binary operator,
+// and a higher precedence binds more tightly than a lower one.
+var binaryPrecedence map[Symbol]int = map[Symbol]int{
+	Wedge: 4,
+	Vee:   3,
+	To:    2,
+	Iff:   1,
+}
+
+// The options behind GetWffString and ParseWff, which are never to be mutated.
+var strictOptions *ParseOptions = NewParseOptions(Strict)
+
+// The options behind ParseSchema, which are never to be mutated.
+var schemaOptions *ParseOptions = &ParseOptions{Mode: Strict, Assoc: strictOptions.Assoc, Schemata: true}
+
+func NewParseOptions(mode ParseMode) (opts *ParseOptions) {
+	opts = &ParseOptions{
+		Mode: mode,
+		Assoc: map[Symbol]Assoc{
+			Wedge: AssocLeft,
+			Vee:   AssocLeft,
+			To:    AssocRight,
+			Iff:   AssocNone,
+		},
+	}
+
+	return
+}
+
+func selectMainBinary(prs *parser, dexesB []int) (dexM int, perr *ParseError) {
+	var (
+		dexesL    []int
+		dex, prec int
+		loosest   int
+	)
+
+	loosest = binaryPrecedence[prs.syms[dexesB[0]]]
+
+	for _, dex = range dexesB {
+		if prec = binaryPrecedence[prs.syms[dex]]; prec < loosest {
+			loosest, dexesL = prec, []int{dex}
+		} else if prec == loosest {
+			dexesL = append(dexesL, dex)
+		}
+	}
+
+	// Each binary operator has its own precedence,
+	// so the loosest binding operators are all the same symbol.
+	switch prs.opts.Assoc[prs.syms[dexesL[0]]] {
+	case AssocLeft:
+		dexM = dexesL[len(dexesL)-1]
+	case AssocRight:
+		dexM = dexesL[0]
+	default:
+		if dexM = dexesL[0]; 1 < len(dexesL) {
+			dexM, perr = -1, newParseError(StepFindMainOperator, ExpectGrouping, prs.offs[dexesL[1]])
+		}
+	}
+
+	return
+}
+
+func needsParens(wff, sub *WffTree, isLeft bool, opts *ParseOptions) (needs bool) {
+	var (
+		precW, precS int
+		assoc        Assoc
+	)
+
+	if sub.kind != Binary {
+		return
+	}
+
+	if wff.kind != Binary || opts.Mode == Strict {
+		needs = true
+
+		return
+	}
+
+	precW, precS = binaryPrecedence[wff.mop], binaryPrecedence[sub.mop]
+
+	switch assoc = opts.Assoc[wff.mop]; {
+	case precW < precS:
+		needs = false
+	case precS < precW:
+		needs = true
+	case isLeft:
+		needs = assoc != AssocLeft
+	default:
+		needs = assoc != AssocRight
+	}
+
+	return
+}
+
+func GetMinimalWffString(wff *WffTree, opts *ParseOptions) (s string) {
+	var (
+		wffL, wffR string
+	)
+
+	switch wff.kind {
+	case Atomic:
+		s = getAtomicString(wff)
+	case Unary, Quantified:
+		if wffL = GetMinimalWffString(wff.subL, opts); needsParens(wff, wff.subL, true, opts) {
+			wffL = "(" + wffL + ")"
+		}
+
+		s = getOperatorString(wff) + wffL
+	case Binary:
+		if wffL = GetMinimalWffString(wff.subL, opts); needsParens(wff, wff.subL, true, opts) {
+			wffL = "(" + wffL + ")"
+		}
+
+		if wffR = GetMinimalWffString(wff.subR, opts); needsParens(wff, wff.subR, false, opts) {
+			wffR = "(" + wffR + ")"
+		}
+
+		s = wffL + string(wff.mop) + wffR
+	default:
+		panic("Invalid WffTree")
+	}
+
+	return
+}
@@ -0,0 +1,276 @@
+package fmla
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// A Rule rewrites any instance of its pattern to the same instance of its replacement.
+type Rule struct {
+	Pattern     *WffTree
+	Replacement *WffTree
+}
+
+// A RewriteStep is one use of a rule, at a position of the formula it changed.
+type RewriteStep struct {
+	Rule     *Rule
+	Position Position
+	After    *WffTree // The whole formula after the step.
+}
+
+// A Rewriting records the steps Rewrite took from a formula, so they can be shown as a chain of equivalences.
+type Rewriting struct {
+	Start *WffTree
+	Steps []RewriteStep
+}
+
+func getMetas(wff *WffTree) (preds []Predicate, args []Argument) {
+	var (
+		sub *WffTree
+		arg Argument
+	)
+
+	for _, sub = range orderAtomics(wff) {
+		if isMetaWff(sub) && !slices.Contains(preds, sub.pred) {
+			preds = append(preds, sub.pred)
+		}
+
+		for _, arg = range argStringToArgs(sub.args) {
+			if IsTermMeta(arg) && !slices.Contains(args, arg) {
+				args = append(args, arg)
+			}
+		}
+	}
+
+	return
+}
+
+// NewRule makes a rule of two schemata. Every schema letter of the replacement must occur in the pattern,
+// and the pattern must not be a lone formula letter, which would rewrite everything.
+func NewRule(pattern, replacement *WffTree) (rule *Rule, err error) {
+	var (
+		predsP, predsR []Predicate
+		argsP, argsR   []Argument
+		pred           Predicate
+		arg            Argument
+	)
+
+	if pattern == nil || replacement == nil {
+		panic("Invalid WffTree")
+	}
+
+	if isMetaWff(pattern) {
+		err = fmt.Errorf("fmla: pattern %s is a lone schema letter", GetWffString(pattern))
+
+		return
+	}
+
+	predsP, argsP = getMetas(pattern)
+
+	predsR, argsR = getMetas(replacement)
+
+	for _, pred = range predsR {
+		if !slices.Contains(predsP, pred) {
+			err = fmt.Errorf("fmla: %s occurs in the replacement but not the pattern", pred)
+
+			return
+		}
+	}
+
+	for _, arg = range argsR {
+		if !slices.Contains(argsP, arg) {
+			err = fmt.Errorf("fmla: %s occurs in the replacement but not the pattern", arg)
+
+			return
+		}
+	}
+
+	rule = &Rule{Pattern: DeepCopy(pattern), Replacement: DeepCopy(replacement)}
+
+	return
+}
+
+// ParseRule reads a rule written as two schemata on either side of ⇒ or =>, as in φ∧⊤ ⇒ φ.
+func ParseRule(s string) (rule *Rule, err error) {
+	var (
+		sP, sR               string
+		pattern, replacement *WffTree
+		found                bool
+	)
+
+	if sP, sR, found = strings.Cut(s, "⇒"); !found {
+		if sP, sR, found = strings.Cut(s, "=>"); !found {
+			err = fmt.Errorf("fmla: rule %q has no ⇒", s)
+
+			return
+		}
+	}
+
+	if pattern, err = ParseSchema(sP); err != nil {
+		return
+	}
+
+	if replacement, err = ParseSchema(sR); err != nil {
+		return
+	}
+
+	rule, err = NewRule(pattern, replacement)
+
+	return
+}
+
+func mustParseRules(ss ...string) (rules []*Rule) {
+	var (
+		s    string
+		rule *Rule
+		err  error
+	)
+
+	for _, s = range ss {
+		if rule, err = ParseRule(s); err != nil {
+			panic(err)
+		}
+
+		rules = append(rules, rule)
+	}
+
+	return
+}
+
+func (rule *Rule) String() (s string) {
+	s = GetWffString(rule.Pattern) + " ⇒ " + GetWffString(rule.Replacement)
+
+	return
+}
+
+// The rules that hold intuitionistically, and so in every logic here; □ and ◇ are read as in K.
+var intuitionisticRules = mustParseRules(
+	"φ∧⊤ ⇒ φ", "⊤∧φ ⇒ φ", "φ∧⊥ ⇒ ⊥", "⊥∧φ ⇒ ⊥", "φ∧φ ⇒ φ",
+	"φ∨⊥ ⇒ φ", "⊥∨φ ⇒ φ", "φ∨⊤ ⇒ ⊤", "⊤∨φ ⇒ ⊤", "φ∨φ ⇒ φ",
+	"φ→⊤ ⇒ ⊤", "⊤→φ ⇒ φ", "⊥→φ ⇒ ⊤", "φ→φ ⇒ ⊤", "φ→⊥ ⇒ ¬φ",
+	"φ↔⊤ ⇒ φ", "⊤↔φ ⇒ φ", "φ↔⊥ ⇒ ¬φ", "⊥↔φ ⇒ ¬φ", "φ↔φ ⇒ ⊤",
+	"φ∧¬φ ⇒ ⊥", "¬φ∧φ ⇒ ⊥",
+	"¬⊤ ⇒ ⊥", "¬⊥ ⇒ ⊤", "¬¬¬φ ⇒ ¬φ",
+	"□⊤ ⇒ ⊤", "◇⊥ ⇒ ⊥",
+)
+
+// The rules that hold only classically.
+var classicalRules = mustParseRules(
+	"¬¬φ ⇒ φ", "φ∨¬φ ⇒ ⊤", "¬φ∨φ ⇒ ⊤",
+)
+
+// SimplificationRules returns the default rules for Simplify, with the classical ones if asked.
+func SimplificationRules(classical bool) (rules []*Rule) {
+	rules = slices.Clone(intuitionisticRules)
+
+	if classical {
+		rules = append(rules, classicalRules...)
+	}
+
+	return
+}
+
+// isSmaller reports whether wffA comes before wffB in the order that bounds rewriting:
+// by size, and then, between formulae of the same size, by Compare.
+func isSmaller(wffA, wffB *WffTree) (is bool) {
+	var (
+		sizeA, sizeB uint
+	)
+
+	sizeA, sizeB = GetWffSize(wffA), GetWffSize(wffB)
+
+	is = sizeA < sizeB || (sizeA == sizeB && Compare(wffA, wffB) < 0)
+
+	return
+}
+
+// rewriteOnce takes the first step it can, at the outermost and then leftmost position, by the first rule that applies.
+func rewriteOnce(wff *WffTree, rules []*Rule) (step RewriteStep, ok bool) {
+	var (
+		pos       Position
+		sub, subR *WffTree
+		rule      *Rule
+		subst     *Substitution
+		matched   bool
+	)
+
+	for _, pos = range Positions(wff) {
+		sub, _ = SubformulaAt(wff, pos)
+
+		for _, rule = range rules {
+			if subst, matched = Match(rule.Pattern, sub); !matched {
+				continue
+			}
+
+			// Each step must go down the order, which keeps any set of rules from rewriting forever.
+			if subR = subst.Apply(rule.Replacement); !isSmaller(subR, sub) {
+				continue
+			}
+
+			step.Rule, step.Position, ok = rule, pos, true
+
+			step.After, _ = ReplaceAt(wff, pos, subR)
+
+			return
+		}
+	}
+
+	return
+}
+
+// Rewrite applies the rules to a formula and its subformulae until none applies.
+// A step is only taken if it makes the subformula smaller, by size and then by Compare,
+// so rewriting always ends, though a rule that would make things bigger is never used.
+func Rewrite(wff *WffTree, rules ...*Rule) (rw *Rewriting) {
+	var (
+		step RewriteStep
+		ok   bool
+	)
+
+	if wff == nil {
+		panic("Invalid WffTree")
+	}
+
+	rw = &Rewriting{Start: DeepCopy(wff)}
+
+	for step, ok = rewriteOnce(rw.Start, rules); ok; step, ok = rewriteOnce(step.After, rules) {
+		rw.Steps = append(rw.Steps, step)
+	}
+
+	return
+}
+
+// Simplify rewrites a formula by SimplificationRules. Each step replaces a subformula
+// with one equivalent to it, in intuitionistic logic or, if classical, in classical logic.
+func Simplify(wff *WffTree, classical bool) (rw *Rewriting) {
+	rw = Rewrite(wff, SimplificationRules(classical)...)
+
+	return
+}
+
+func (rw *Rewriting) Result() (wff *WffTree) {
+	if wff = rw.Start; 0 < len(rw.Steps) {
+		wff = rw.Steps[len(rw.Steps)-1].After
+	}
+
+	return
+}
+
+// String writes the rewriting as a chain of equivalences, one step to a line, each with its rule and position.
+func (rw *Rewriting) String() (s string) {
+	var (
+		sb   strings.Builder
+		step RewriteStep
+	)
+
+	sb.WriteString(GetWffString(rw.Start))
+
+	for _, step = range rw.Steps {
+		fmt.Fprintf(&sb, "\n≡ %s\tby %s at %s", GetWffString(step.After), step.Rule, step.Position)
+	}
+
+	s = sb.String()
+
+	return
+}
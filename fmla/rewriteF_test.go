@@ -0,0 +1,81 @@
+package fmla
+
+import (
+	"testing"
+)
+
+func TestSimplify(t *testing.T) {
+	type testCase struct {
+		s         string
+		classical bool
+		expected  string
+	}
+
+	var (
+		tcs []testCase
+		tc  testCase
+		wff *WffTree
+		rw  *Rewriting
+		s   string
+		err error
+	)
+
+	tcs = []testCase{
+		{"(P∧⊤)∨(P∧⊤)", false, "P"},
+		{"¬¬(P∧⊤)", false, "¬¬P"},
+		{"¬¬(P∧⊤)", true, "P"},
+		{"□⊤→(Q∨¬Q)", true, "⊤"},
+		{"□⊤→(Q∨¬Q)", false, "Q∨¬Q"},
+		{"∀x(Fx→⊥)", false, "∀x¬Fx"},
+	}
+
+	for _, tc = range tcs {
+		if wff, err = ParseWff(tc.s); err != nil {
+			t.Fatalf("\nFAILED: %v", err)
+		}
+
+		if rw = Simplify(wff, tc.classical); GetWffString(rw.Result()) != tc.expected {
+			t.Errorf("\nFAILED: Expected %q to simplify to %q, got:\n%s", tc.s, tc.expected, rw)
+		} else {
+			t.Logf("\nPASSED:\n%s", rw)
+		}
+	}
+
+	if wff, err = ParseWff("(P∧⊤)∨Q"); err != nil {
+		t.Fatalf("\nFAILED: %v", err)
+	}
+
+	if s = Simplify(wff, false).String(); s != "(P∧⊤)∨Q\n≡ P∨Q\tby φ∧⊤ ⇒ φ at 1" {
+		t.Errorf("\nFAILED: Unexpected chain:\n%s", s)
+	}
+}
+
+func TestRewrite(t *testing.T) {
+	var (
+		rule, ruleG *Rule
+		wff         *WffTree
+		rw          *Rewriting
+		err         error
+	)
+
+	// Commuting disjuncts both ways would loop, but only the step down the order is taken.
+	if rule, err = ParseRule("φ∨ψ ⇒ ψ∨φ"); err != nil {
+		t.Fatalf("\nFAILED: %v", err)
+	}
+
+	if wff, err = ParseWff("Q∨P"); err != nil {
+		t.Fatalf("\nFAILED: %v", err)
+	}
+
+	if rw = Rewrite(wff, rule); GetWffString(rw.Result()) != "P∨Q" || len(rw.Steps) != 1 {
+		t.Errorf("\nFAILED: Expected one step to P∨Q, got:\n%s", rw)
+	}
+
+	if ruleG, err = ParseRule("φ ⇒ φ∧φ"); err == nil {
+		t.Errorf("\nFAILED: Expected a lone schema letter to be refused as a pattern, got %s.", ruleG)
+	}
+
+	if _, err = ParseRule("φ∧ψ ⇒ χ"); err == nil {
+		t.Errorf("\nFAILED: Expected a replacement with a new schema letter to be refused.")
+	}
+}
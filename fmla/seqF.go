@@ -0,0 +1,427 @@
+package fmla
+
+import (
+	"context"
+	"iter"
+	"slices"
+	"sync"
+)
+
+// The Seq versions of the Build functions yield the same formulae in the same order,
+// but run in the caller's goroutine, so breaking out of a range over them leaves nothing behind.
+
+func AtomicWffs(domP uint, domA uint, arity uint) (wffs iter.Seq[*WffTree]) {
+	wffs = func(yield func(*WffTree) bool) {
+		var (
+			dex  uint
+			pc   Predicate
+			tups [][]Argument
+			tup  []Argument
+			lenT uint
+		)
+
+		if domP == 0 {
+			_ = yield(NewAtomicWff(Top)) && yield(NewAtomicWff(Bot))
+
+			return
+		}
+
+		tups, lenT = buildTupsAtArity(domA, arity)
+
+		for dex = 0; dex < domP; dex += 1 {
+			pc = NthPredConst(dex)
+
+			if lenT == 0 {
+				if !yield(NewAtomicWff(pc)) {
+					return
+				}
+
+				continue
+			}
+
+			for _, tup = range tups {
+				if !yield(NewAtomicWff(pc, tup...)) {
+					return
+				}
+			}
+		}
+
+		if arity == 2 {
+			for _, tup = range tups {
+				if !yield(NewAtomicWff(Equals, tup...)) {
+					return
+				}
+			}
+		}
+	}
+
+	return
+}
+
+func MixedAtomicWffs(maxDomP uint, maxDomA uint, maxArity uint) (wffs iter.Seq[*WffTree]) {
+	wffs = func(yield func(*WffTree) bool) {
+		var (
+			ity  uint
+			pwff *WffTree
+		)
+
+		// Get Top and Bot.
+		for pwff = range AtomicWffs(0, 0, 0) {
+			if !yield(pwff) {
+				return
+			}
+		}
+
+		if maxDomP == 0 {
+			return
+		}
+
+		// Get 0-place predicates.
+		for pwff = range AtomicWffs(maxDomP, 0, 0) {
+			if !yield(pwff) {
+				return
+			}
+		}
+
+		if maxDomA == 0 {
+			return
+		}
+
+		for ity = 1; ity < maxArity+1; ity += 1 {
+			for pwff = range AtomicWffs(maxDomP, maxDomA, ity) {
+				if !yield(pwff) {
+					return
+				}
+			}
+		}
+	}
+
+	return
+}
+
+// compositesOver yields the formulae of a nesting level whose left, or only, subformula is subL,
+// in the order of BuildCompositeWffs.
+func compositesOver(subL *WffTree, subs []*WffTree, domP, domA uint) (wffs iter.Seq[*WffTree]) {
+	wffs = func(yield func(*WffTree) bool) {
+		var (
+			sym  Symbol
+			dex  uint
+			subR *WffTree
+		)
+
+		for _, sym = range UnaryOps {
+			if !yield(NewCompositeWff(sym, subL, nil, 0, 0)) {
+				return
+			}
+		}
+
+		for _, sym = range Quantifiers {
+			for dex = 0; dex < domP+1; dex += 1 {
+				if !yield(NewCompositeWff(sym, subL, nil, NthPredConst(dex), 0)) {
+					return
+				}
+			}
+
+			for dex = 0; dex < domA+1; dex += 1 {
+				if !yield(NewCompositeWff(sym, subL, nil, 0, NthArgConst(dex))) {
+					return
+				}
+			}
+		}
+
+		for _, subR = range subs {
+			for _, sym = range BinaryOps {
+				if !yield(NewCompositeWff(sym, subL, subR, 0, 0)) {
+					return
+				}
+			}
+		}
+	}
+
+	return
+}
+
+// CompositeWffs yields the formulae of BuildCompositeWffs. The level below is gathered once
+// and kept while the level is yielded, rather than built again for each left subformula.
+func CompositeWffs(nest uint, domP uint, domA uint, arity uint) (wffs iter.Seq[*WffTree]) {
+	if nest == 0 {
+		wffs = MixedAtomicWffs(domP, domA, arity)
+
+		return
+	}
+
+	wffs = func(yield func(*WffTree) bool) {
+		var (
+			subs       []*WffTree
+			subL, wffC *WffTree
+		)
+
+		subs = slices.Collect(CompositeWffs(nest-1, domP, domA, arity))
+
+		for _, subL = range subs {
+			for wffC = range compositesOver(subL, subs, domP, domA) {
+				if !yield(wffC) {
+					return
+				}
+			}
+		}
+	}
+
+	return
+}
+
+// CanonicalWffs yields the formulae of wffs that are canonical, as KeepCanonicalWffs sends them.
+func CanonicalWffs(wffs iter.Seq[*WffTree]) (cwffs iter.Seq[*WffTree]) {
+	cwffs = func(yield func(*WffTree) bool) {
+		var (
+			wff *WffTree
+		)
+
+		for wff = range wffs {
+			if IsCanonical(wff) && !yield(wff) {
+				return
+			}
+		}
+	}
+
+	return
+}
+
+// ACCanonicalWffs yields the formulae of wffs that are AC-canonical, as KeepACCanonicalWffs sends them.
+func ACCanonicalWffs(wffs iter.Seq[*WffTree]) (cwffs iter.Seq[*WffTree]) {
+	cwffs = func(yield func(*WffTree) bool) {
+		var (
+			wff *WffTree
+		)
+
+		for wff = range wffs {
+			if IsACCanonical(wff) && !yield(wff) {
+				return
+			}
+		}
+	}
+
+	return
+}
+
+// WithContext yields the formulae of wffs until the context is done.
+func WithContext(ctx context.Context, wffs iter.Seq[*WffTree]) (wffsC iter.Seq[*WffTree]) {
+	wffsC = func(yield func(*WffTree) bool) {
+		var (
+			wff *WffTree
+		)
+
+		for wff = range wffs {
+			if ctx.Err() != nil || !yield(wff) {
+				return
+			}
+		}
+	}
+
+	return
+}
+
+// seqToChan sends the formulae of wffs on a channel, which is closed once they run out or the context is done.
+func seqToChan(ctx context.Context, wffs iter.Seq[*WffTree]) (wffsC chan *WffTree) {
+	wffsC = make(chan *WffTree)
+
+	go func() {
+		var (
+			wff *WffTree
+		)
+
+		defer close(wffsC)
+
+		for wff = range wffs {
+			select {
+			case wffsC <- wff:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return
+}
+
+// chanToSeq yields the formulae received on a channel until it's closed.
+func chanToSeq(wffs chan *WffTree) (wffsS iter.Seq[*WffTree]) {
+	wffsS = func(yield func(*WffTree) bool) {
+		var (
+			wff *WffTree
+		)
+
+		for wff = range wffs {
+			if !yield(wff) {
+				return
+			}
+		}
+	}
+
+	return
+}
+
+// BuildAtomicWffsContext is BuildAtomicWffs, but stops and closes its channel once the context is done.
+func BuildAtomicWffsContext(ctx context.Context, domP uint, domA uint, arity uint) (wffs chan *WffTree) {
+	wffs = seqToChan(ctx, AtomicWffs(domP, domA, arity))
+
+	return
+}
+
+// BuildMixedAtomicWffsContext is BuildMixedAtomicWffs, but stops and closes its channel once the context is done.
+func BuildMixedAtomicWffsContext(ctx context.Context, maxDomP uint, maxDomA uint, maxArity uint) (wffs chan *WffTree) {
+	wffs = seqToChan(ctx, MixedAtomicWffs(maxDomP, maxDomA, maxArity))
+
+	return
+}
+
+// BuildCompositeWffsContext is BuildCompositeWffs, but stops and closes its channel once the context is done,
+// so that a consumer that stops early can cancel the context instead of draining the channel.
+func BuildCompositeWffsContext(ctx context.Context, nest uint, domP uint, domA uint, arity uint) (wffs chan *WffTree) {
+	wffs = seqToChan(ctx, CompositeWffs(nest, domP, domA, arity))
+
+	return
+}
+
+// KeepCanonicalWffsContext is KeepCanonicalWffs, but stops and closes its channel once the context is done.
+// It then stops receiving as well, so wffs should come from a Context builder with the same context.
+func KeepCanonicalWffsContext(ctx context.Context, wffs chan *WffTree) (cwffs chan *WffTree) {
+	cwffs = seqToChan(ctx, CanonicalWffs(chanToSeq(wffs)))
+
+	return
+}
+
+// ParallelBlockSize is how many formulae a worker of ParallelCompositeWffs may build ahead of the consumer.
+const ParallelBlockSize = 256
+
+// ParallelCompositeWffs yields the formulae of CompositeWffs, in the same order, until the context is done.
+// The formulae over each left subformula are built by one of a pool of workers,
+// so that later ones are built while earlier ones are yielded.
+// Every worker has stopped by the time the range over it ends.
+func ParallelCompositeWffs(ctx context.Context, nest uint, domP uint, domA uint, arity uint, workers int) (wffs iter.Seq[*WffTree]) {
+	type job struct {
+		subL *WffTree
+		out  chan *WffTree
+	}
+
+	if nest == 0 || workers < 2 {
+		wffs = WithContext(ctx, CompositeWffs(nest, domP, domA, arity))
+
+		return
+	}
+
+	wffs = func(yield func(*WffTree) bool) {
+		var (
+			ctxW   context.Context
+			cancel context.CancelFunc
+			wg     sync.WaitGroup
+			subs   []*WffTree
+			jobs   chan job
+			order  chan chan *WffTree
+			out    chan *WffTree
+			wff    *WffTree
+			ok     bool
+			dex    int
+			send   func(out chan *WffTree, wff *WffTree) (sent bool)
+			work   func()
+		)
+
+		ctxW, cancel = context.WithCancel(ctx)
+
+		// Cancelling first lets every goroutine finish, then waiting makes sure they have.
+		defer wg.Wait()
+		defer cancel()
+
+		if subs = slices.Collect(ParallelCompositeWffs(ctxW, nest-1, domP, domA, arity, workers)); ctxW.Err() != nil {
+			return
+		}
+
+		jobs, order = make(chan job), make(chan chan *WffTree, workers)
+
+		// Jobs are handed out in order, and their outputs queued in the same order for the consumer.
+		wg.Add(1)
+
+		go func() {
+			var (
+				subL *WffTree
+				out  chan *WffTree
+			)
+
+			defer wg.Done()
+			defer close(jobs)
+			defer close(order)
+
+			for _, subL = range subs {
+				out = make(chan *WffTree, ParallelBlockSize)
+
+				select {
+				case order <- out:
+				case <-ctxW.Done():
+					return
+				}
+
+				select {
+				case jobs <- job{subL, out}:
+				case <-ctxW.Done():
+					return
+				}
+			}
+		}()
+
+		send = func(out chan *WffTree, wff *WffTree) (sent bool) {
+			select {
+			case out <- wff:
+				sent = true
+			case <-ctxW.Done():
+			}
+
+			return
+		}
+
+		work = func() {
+			var (
+				jb  job
+				wff *WffTree
+			)
+
+			defer wg.Done()
+
+			for jb = range jobs {
+				for wff = range compositesOver(jb.subL, subs, domP, domA) {
+					if !send(jb.out, wff) {
+						break
+					}
+				}
+
+				close(jb.out)
+			}
+		}
+
+		for dex = 0; dex < workers; dex += 1 {
+			wg.Add(1)
+
+			go work()
+		}
+
+		for out = range order {
+			for {
+				select {
+				case wff, ok = <-out:
+				case <-ctxW.Done():
+					return
+				}
+
+				if !ok {
+					break
+				}
+
+				// A value and the cancellation can both be ready, so check again before yielding.
+				if ctxW.Err() != nil || !yield(wff) {
+					return
+				}
+			}
+		}
+	}
+
+	return
+}
@@ -0,0 +1,72 @@
+package fmla
+
+import (
+	"context"
+	"runtime"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestParallelCompositeWffs(t *testing.T) {
+	var (
+		wffs, wffsP []*WffTree
+		wff         *WffTree
+		ctx         context.Context
+		cancel      context.CancelFunc
+		n, before   int
+	)
+
+	before = runtime.NumGoroutine()
+
+	wffs = slices.Collect(CompositeWffs(2, 1, 1, 1))
+
+	if wffsP = slices.Collect(ParallelCompositeWffs(context.Background(), 2, 1, 1, 1, 4)); !slices.Equal(wffs, wffsP) {
+		t.Errorf("\nFAILED: Expected the %d formulae of CompositeWffs in order, got %d.", len(wffs), len(wffsP))
+	}
+
+	// Breaking out early must stop the workers.
+	for wff = range ParallelCompositeWffs(context.Background(), 2, 1, 1, 1, 4) {
+		if n += 1; n == 100 {
+			break
+		}
+	}
+
+	if wff != wffs[99] {
+		t.Errorf("\nFAILED: Expected the 100th formula to be %q, got %q.", GetWffString(wffs[99]), GetWffString(wff))
+	}
+
+	ctx, cancel = context.WithCancel(context.Background())
+
+	defer cancel()
+
+	n = 0
+
+	for range ParallelCompositeWffs(ctx, 2, 1, 1, 1, 4) {
+		if n += 1; n == 100 {
+			cancel()
+		}
+	}
+
+	if n != 100 {
+		t.Errorf("\nFAILED: Expected cancellation to stop the formulae at 100, got %d.", n)
+	}
+
+	// The channel versions of the past would still have goroutines blocked here.
+	for range BuildCompositeWffsContext(ctx, 2, 1, 1, 1) {
+	}
+
+	for range KeepCanonicalWffsContext(ctx, BuildAtomicWffsContext(ctx, 2, 2, 2)) {
+	}
+
+	for range BuildMixedAtomicWffsContext(ctx, 2, 2, 2) {
+	}
+
+	for n = 0; n < 50 && before < runtime.NumGoroutine(); n += 1 {
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	if before < runtime.NumGoroutine() {
+		t.Errorf("\nFAILED: Expected %d goroutines after stopping, got %d.", before, runtime.NumGoroutine())
+	}
+}
@@ -0,0 +1,316 @@
+package fmla
+
+import (
+	"iter"
+	"maps"
+	"math/big"
+	"slices"
+)
+
+// A SizeMeasure says how the size of a formula is counted.
+type SizeMeasure int
+
+const (
+	Nodes   SizeMeasure = iota + 1 // Each atomic formula, operator and quantifier counts once.
+	Symbols                        // Each predicate, argument, operator and bound variable counts once, but not parentheses or commas.
+)
+
+// A Signature is what EnumerateWffs builds formulae from. Function symbols aren't part of it,
+// and bound variables are named by how many quantifiers enclose them, as NthArgVar names them: u for the outermost, then v and so on,
+// so that each formula stands for all those that differ from it only in the names of their bound variables.
+type Signature struct {
+	Preds  map[Predicate]uint // The arity of each predicate constant, and of ⊤, ⊥ and =, if they may be used.
+	Consts []Argument         // The argument constants.
+	Ops    []Symbol           // The operators and quantifiers that may be used, in the order they are tried.
+}
+
+func measureWff(wff *WffTree, measure SizeMeasure) (size uint) {
+	switch wff.kind {
+	case Atomic:
+		if size = 1; measure == Symbols {
+			size += uint(len(argStringToArgs(wff.args)))
+		}
+	case Unary:
+		size = measureWff(wff.subL, measure) + 1
+	case Binary:
+		size = measureWff(wff.subL, measure) + measureWff(wff.subR, measure) + 1
+	case Quantified:
+		if size = measureWff(wff.subL, measure) + 1; measure == Symbols {
+			size += 1
+		}
+	default:
+		panic("Invalid WffTree")
+	}
+
+	return
+}
+
+// sizer holds what EnumerateWffs and CountWffs share: the signature in order and the sizes of its parts.
+type sizer struct {
+	preds   []Predicate
+	arities map[Predicate]uint
+	consts  []Argument
+	unops   []Symbol
+	binops  []Symbol
+	quants  []Symbol
+	measure SizeMeasure
+	counts  map[[2]uint]*big.Int // The count of formulae of each size with each number of bound variables in scope.
+}
+
+func newSizer(sig *Signature, measure SizeMeasure) (sz *sizer) {
+	var (
+		pred Predicate
+		sym  Symbol
+	)
+
+	if sig == nil || (measure != Nodes && measure != Symbols) {
+		panic("Invalid signature.")
+	}
+
+	sz = &sizer{
+		preds:   slices.Sorted(maps.Keys(sig.Preds)),
+		arities: sig.Preds,
+		consts:  sig.Consts,
+		measure: measure,
+		counts:  map[[2]uint]*big.Int{},
+	}
+
+	for _, pred = range sz.preds {
+		switch {
+		case pred == Top || pred == Bot:
+			if sig.Preds[pred] != 0 {
+				panic("Invalid signature.")
+			}
+		case pred == Equals:
+			if sig.Preds[pred] != 2 {
+				panic("Invalid signature.")
+			}
+		case !IsPredConst(pred):
+			panic("Invalid signature.")
+		}
+	}
+
+	for _, sym = range sig.Ops {
+		switch {
+		case slices.Contains(UnaryOps, sym):
+			sz.unops = append(sz.unops, sym)
+		case slices.Contains(BinaryOps, sym):
+			sz.binops = append(sz.binops, sym)
+		case slices.Contains(Quantifiers, sym):
+			sz.quants = append(sz.quants, sym)
+		default:
+			panic("Invalid signature.")
+		}
+	}
+
+	return
+}
+
+func (sz *sizer) atomSize(pred Predicate) (size uint) {
+	if size = 1; sz.measure == Symbols {
+		size += sz.arities[pred]
+	}
+
+	return
+}
+
+func (sz *sizer) quantSize() (size uint) {
+	if size = 1; sz.measure == Symbols {
+		size = 2
+	}
+
+	return
+}
+
+// count returns the number of formulae of size n with bound variables u, v, ... in scope, d of them.
+func (sz *sizer) count(n, d uint) (c *big.Int) {
+	var (
+		ok     bool
+		pred   Predicate
+		i      uint
+		prod   *big.Int
+		lenOps *big.Int
+	)
+
+	if c, ok = sz.counts[[2]uint{n, d}]; ok {
+		return
+	}
+
+	c = new(big.Int)
+
+	if n == 0 {
+		return
+	}
+
+	for _, pred = range sz.preds {
+		if sz.atomSize(pred) == n {
+			c.Add(c, new(big.Int).Exp(big.NewInt(int64(len(sz.consts))+int64(d)), big.NewInt(int64(sz.arities[pred])), nil))
+		}
+	}
+
+	lenOps = big.NewInt(int64(len(sz.unops)))
+
+	c.Add(c, new(big.Int).Mul(lenOps, sz.count(n-1, d)))
+
+	lenOps = big.NewInt(int64(len(sz.binops)))
+
+	for i = 1; i+1 < n; i += 1 {
+		prod = new(big.Int).Mul(sz.count(i, d), sz.count(n-1-i, d))
+
+		c.Add(c, prod.Mul(prod, lenOps))
+	}
+
+	if sz.quantSize() < n {
+		lenOps = big.NewInt(int64(len(sz.quants)))
+
+		c.Add(c, new(big.Int).Mul(lenOps, sz.count(n-sz.quantSize(), d+1)))
+	}
+
+	sz.counts[[2]uint{n, d}] = c
+
+	return
+}
+
+// atoms yields the atomic formulae of size n with d bound variables in scope.
+func (sz *sizer) atoms(n, d uint) (wffs iter.Seq[*WffTree]) {
+	wffs = func(yield func(*WffTree) bool) {
+		var (
+			pred   Predicate
+			args   []Argument
+			tup    []Argument
+			digits []int
+			dex    int
+			i      uint
+		)
+
+		args = slices.Clone(sz.consts)
+
+		for i = 0; i < d; i += 1 {
+			args = append(args, NthArgVar(i))
+		}
+
+		for _, pred = range sz.preds {
+			if sz.atomSize(pred) != n {
+				continue
+			}
+
+			if sz.arities[pred] != 0 && len(args) == 0 {
+				continue
+			}
+
+			// The tuples of arguments are counted off like the digits of an odometer.
+			for digits = make([]int, sz.arities[pred]); ; {
+				tup = []Argument{}
+
+				for _, dex = range digits {
+					tup = append(tup, args[dex])
+				}
+
+				if !yield(NewAtomicWff(pred, tup...)) {
+					return
+				}
+
+				for dex = len(digits) - 1; -1 < dex; dex -= 1 {
+					if digits[dex] += 1; digits[dex] < len(args) {
+						break
+					}
+
+					digits[dex] = 0
+				}
+
+				if dex == -1 {
+					break
+				}
+			}
+		}
+	}
+
+	return
+}
+
+// wffs yields the formulae of size n with d bound variables in scope: the atomic ones,
+// then those by each unary operator, each binary operator with ever larger left subformulae, and each quantifier.
+func (sz *sizer) wffs(n, d uint) (wffs iter.Seq[*WffTree]) {
+	wffs = func(yield func(*WffTree) bool) {
+		var (
+			wff, subL, subR *WffTree
+			sym             Symbol
+			i               uint
+		)
+
+		if n == 0 || sz.count(n, d).Sign() == 0 {
+			return
+		}
+
+		for wff = range sz.atoms(n, d) {
+			if !yield(wff) {
+				return
+			}
+		}
+
+		for _, sym = range sz.unops {
+			for subL = range sz.wffs(n-1, d) {
+				if !yield(NewCompositeWff(sym, subL, nil, 0, 0)) {
+					return
+				}
+			}
+		}
+
+		for _, sym = range sz.binops {
+			for i = 1; i+1 < n; i += 1 {
+				for subL = range sz.wffs(i, d) {
+					for subR = range sz.wffs(n-1-i, d) {
+						if !yield(NewCompositeWff(sym, subL, subR, 0, 0)) {
+							return
+						}
+					}
+				}
+			}
+		}
+
+		if n <= sz.quantSize() {
+			return
+		}
+
+		for _, sym = range sz.quants {
+			for subL = range sz.wffs(n-sz.quantSize(), d+1) {
+				if !yield(NewCompositeWff(sym, subL, nil, 0, NthArgVar(d))) {
+					return
+				}
+			}
+		}
+	}
+
+	return
+}
+
+// EnumerateWffs yields every closed formula of the signature of size n, by the measure, in a fixed order.
+// There are CountWffs of them.
+func EnumerateWffs(sig *Signature, n uint, measure SizeMeasure) (wffs iter.Seq[*WffTree]) {
+	wffs = newSizer(sig, measure).wffs(n, 0)
+
+	return
+}
+
+// CountWffs returns how many formulae EnumerateWffs yields, without building them.
+func CountWffs(sig *Signature, n uint, measure SizeMeasure) (count *big.Int) {
+	count = new(big.Int).Set(newSizer(sig, measure).count(n, 0))
+
+	return
+}
+
+// CountWffsUpTo returns how many formulae EnumerateWffs yields for each size from 1 to n, all told.
+func CountWffsUpTo(sig *Signature, n uint, measure SizeMeasure) (count *big.Int) {
+	var (
+		sz *sizer
+		i  uint
+	)
+
+	sz, count = newSizer(sig, measure), new(big.Int)
+
+	for i = 1; i < n+1; i += 1 {
+		count.Add(count, sz.count(i, 0))
+	}
+
+	return
+}
@@ -0,0 +1,64 @@
+package fmla
+
+import (
+	"math/big"
+	"slices"
+	"testing"
+)
+
+func TestEnumerateWffs(t *testing.T) {
+	var (
+		sigP, sigF *Signature
+		sig        *Signature
+		measure    SizeMeasure
+		n          uint
+		wffs       []*WffTree
+		wff        *WffTree
+		ss         []string
+		s          string
+	)
+
+	sigP = &Signature{Preds: map[Predicate]uint{'P': 0}, Ops: []Symbol{Neg, Wedge}}
+
+	for wff = range EnumerateWffs(sigP, 4, Nodes) {
+		ss = append(ss, GetWffString(wff))
+	}
+
+	if !slices.Equal(ss, []string{"¬¬¬P", "¬(P∧P)", "P∧¬P", "¬P∧P"}) {
+		t.Errorf("\nFAILED: Unexpected formulae of 4 nodes: %q.", ss)
+	}
+
+	sigF = &Signature{
+		Preds:  map[Predicate]uint{'F': 1, Equals: 2, Bot: 0},
+		Consts: []Argument{'a'},
+		Ops:    []Symbol{Neg, To, Box, ForAll, Exists},
+	}
+
+	for _, sig = range []*Signature{sigP, sigF} {
+		for _, measure = range []SizeMeasure{Nodes, Symbols} {
+			for n = 1; n < 7; n += 1 {
+				wffs = slices.Collect(EnumerateWffs(sig, n, measure))
+
+				if CountWffs(sig, n, measure).Cmp(big.NewInt(int64(len(wffs)))) != 0 {
+					t.Errorf("\nFAILED: Counted %s formulae of size %d, enumerated %d.", CountWffs(sig, n, measure), n, len(wffs))
+				}
+
+				for _, wff = range wffs {
+					if measureWff(wff, measure) != n || !isClosedWff(wff) {
+						t.Errorf("\nFAILED: %q isn't a closed formula of size %d.", GetWffString(wff), n)
+					}
+				}
+
+				slices.SortFunc(wffs, Compare)
+
+				if len(slices.Compact(wffs)) != len(wffs) {
+					t.Errorf("\nFAILED: Formulae of size %d were enumerated twice.", n)
+				}
+			}
+		}
+	}
+
+	if s = CountWffsUpTo(sigP, 4, Nodes).String(); s != "8" {
+		t.Errorf("\nFAILED: Expected 8 formulae of up to 4 nodes, got %s.", s)
+	}
+}
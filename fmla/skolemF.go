@@ -0,0 +1,298 @@
+package fmla
+
+import (
+	"slices"
+)
+
+// IsPrenex reports whether a formula is a string of quantifiers over a quantifier-free matrix.
+func IsPrenex(wff *WffTree) (is bool) {
+	if wff == nil {
+		panic("Invalid WffTree")
+	}
+
+	for wff.kind == Quantified {
+		wff = wff.subL
+	}
+
+	is = !hasQuantifier(wff)
+
+	return
+}
+
+func hasModalOp(wff *WffTree) (has bool) {
+	has = slices.ContainsFunc(AllSubformulae(wff), func(sub *WffTree) (is bool) {
+		is = sub.kind == Unary && sub.mop != Neg
+
+		return
+	})
+
+	return
+}
+
+func getFunctions(wff *WffTree) (funs []Function) {
+	var (
+		atom *WffTree
+		arg  Argument
+	)
+
+	for _, atom = range orderAtomics(wff) {
+		for _, arg = range argStringToArgs(atom.args) {
+			if IsFunction(Function(arg)) && !slices.Contains(funs, Function(arg)) {
+				funs = append(funs, Function(arg))
+			}
+		}
+	}
+
+	return
+}
+
+// freshFunction returns the first function symbol of the arity whose name isn't used at any arity,
+// trying f, g, ..., t and then a, b, ..., e at each index in turn.
+func freshFunction(arity uint, used []Function) (fun Function) {
+	var (
+		idx    uint
+		n      rune
+		letter rune
+	)
+
+	for idx = 0; idx <= MaxFuncIndex; idx += 1 {
+		for n = range rune(len(ArgConsts)) {
+			letter = 'a' + ('f'-'a'+n)%rune(len(ArgConsts))
+
+			if !slices.ContainsFunc(used, func(funU Function) (same bool) {
+				same = funU.String() == NewFunction(letter, idx, 1).String()
+
+				return
+			}) {
+				fun = NewFunction(letter, idx, arity)
+
+				return
+			}
+		}
+	}
+
+	panic("No function symbols left.")
+}
+
+func freshConstant(used []Argument) (arg Argument) {
+	var (
+		n uint
+	)
+
+	for arg = NthArgConst(n); slices.Contains(used, arg); arg = NthArgConst(n) {
+		n += 1
+	}
+
+	return
+}
+
+// Skolemize removes the existential quantifiers from a first-order prenex formula.
+// Each ∃x under the universal quantifiers ∀y₁...∀yₙ gives way to a fresh function term f(y₁,...,yₙ),
+// or, with none, to a fresh argument constant.
+// The result is satisfiable just when the formula is, in classical logic, but isn't equivalent to it.
+func Skolemize(wff *WffTree) (wffS *WffTree) {
+	var (
+		univs  []*Term
+		prefix []quantifier
+		q      quantifier
+		matrix *WffTree
+		funs   []Function
+		fun    Function
+		acs    []Argument
+		ac     Argument
+	)
+
+	if wff == nil {
+		panic("Invalid WffTree")
+	}
+
+	if !IsPrenex(wff) {
+		panic("WffTree is not in prenex form.")
+	}
+
+	if hasModalOp(wff) {
+		panic("WffTree is modal.")
+	}
+
+	// A prefix such as ∃x∀x rebinds its variable, and the inner x must not become the Skolem term.
+	for matrix = rectifyFormula(wff); matrix.kind == Quantified; matrix = matrix.subL {
+		if matrix.pVar != 0 {
+			panic("WffTree quantifies over a predicate variable.")
+		}
+
+		prefix = append(prefix, quantifier{matrix.mop, 0, matrix.aVar})
+	}
+
+	funs = getFunctions(wff)
+
+	_, acs = GetConstants(wff)
+
+	for _, q = range prefix {
+		switch {
+		case q.mop == ForAll:
+			univs = append(univs, NewArgTerm(q.aVar))
+		case len(univs) == 0:
+			ac = freshConstant(acs)
+
+			acs = append(acs, ac)
+
+			matrix = SubstituteTerm(matrix, q.aVar, NewArgTerm(ac))
+		default:
+			fun = freshFunction(uint(len(univs)), funs)
+
+			funs = append(funs, fun)
+
+			matrix = SubstituteTerm(matrix, q.aVar, NewFuncTerm(fun, univs...))
+		}
+	}
+
+	wffS = matrix
+
+	for _, q = range slices.Backward(prefix) {
+		if q.mop == ForAll {
+			wffS = NewCompositeWff(ForAll, wffS, nil, 0, q.aVar)
+		}
+	}
+
+	return
+}
+
+// HerbrandUniverse returns the ground terms built from the constants by applying
+// the function symbols at most depth times over, shallowest first.
+func HerbrandUniverse(acs []Argument, funs []Function, depth uint) (ts []*Term) {
+	var (
+		ac         Argument
+		fun        Function
+		arity, d   uint
+		level, tup []*Term
+		tups       [][]*Term
+		t          *Term
+		seen       map[ArgString]bool
+		build      func(n uint) (tups [][]*Term)
+	)
+
+	seen = map[ArgString]bool{}
+
+	for _, ac = range acs {
+		if t = NewArgTerm(ac); !seen[termsToArgString(t)] {
+			seen[termsToArgString(t)], ts = true, append(ts, t)
+		}
+	}
+
+	// build returns every n-tuple of the terms so far.
+	build = func(n uint) (tups [][]*Term) {
+		var (
+			rest [][]*Term
+			sub  *Term
+		)
+
+		if n == 0 {
+			tups = [][]*Term{{}}
+
+			return
+		}
+
+		rest = build(n - 1)
+
+		for _, sub = range ts {
+			for _, tup = range rest {
+				tups = append(tups, append([]*Term{sub}, tup...))
+			}
+		}
+
+		return
+	}
+
+	for d = 0; d < depth; d += 1 {
+		level = []*Term{}
+
+		for _, fun = range funs {
+			_, _, arity = SplitFunction(fun)
+
+			tups = build(arity)
+
+			for _, tup = range tups {
+				if t = NewFuncTerm(fun, tup...); !seen[termsToArgString(t)] {
+					seen[termsToArgString(t)], level = true, append(level, t)
+				}
+			}
+		}
+
+		ts = append(ts, level...)
+	}
+
+	return
+}
+
+// HerbrandExpansion returns the distinct ground instances of a universal sentence, ∀x₁...∀xₙA with A quantifier-free,
+// that put terms of its Herbrand universe, to the given depth, for x₁ through xₙ.
+// The universe is built on the constants given, or, failing those, the sentence's own, or, failing those, a.
+// Being quantifier-free and ground, the instances are propositional, with atomic formulae as atoms.
+func HerbrandExpansion(wff *WffTree, acs []Argument, depth uint) (wffsG []*WffTree) {
+	var (
+		avs       []Argument
+		ts        []*Term
+		matrix    *WffTree
+		insts     []*WffTree
+		inst      *WffTree
+		av        Argument
+		t         *Term
+		freeAvs   []Argument
+		seen      map[uint64][]*WffTree
+		instsNext []*WffTree
+	)
+
+	if wff == nil {
+		panic("Invalid WffTree")
+	}
+
+	for matrix = wff; matrix.kind == Quantified; matrix = matrix.subL {
+		if matrix.mop != ForAll || matrix.pVar != 0 {
+			panic("WffTree is not a universal first-order sentence.")
+		}
+
+		avs = append(avs, matrix.aVar)
+	}
+
+	if _, freeAvs = GetFreeVariables(wff); hasQuantifier(matrix) || 0 < len(freeAvs) {
+		panic("WffTree is not a universal first-order sentence.")
+	}
+
+	if len(acs) == 0 {
+		_, acs = GetConstants(wff)
+	}
+
+	if len(acs) == 0 {
+		acs = []Argument{NthArgConst(0)}
+	}
+
+	ts = HerbrandUniverse(acs, getFunctions(wff), depth)
+
+	insts = []*WffTree{matrix}
+
+	for _, av = range avs {
+		instsNext = []*WffTree{}
+
+		for _, inst = range insts {
+			for _, t = range ts {
+				instsNext = append(instsNext, SubstituteTerm(inst, av, t))
+			}
+		}
+
+		insts = instsNext
+	}
+
+	seen = map[uint64][]*WffTree{}
+
+	for _, inst = range insts {
+		if !slices.ContainsFunc(seen[inst.h], func(wffS *WffTree) (is bool) {
+			is = Equal(wffS, inst)
+
+			return
+		}) {
+			seen[inst.h], wffsG = append(seen[inst.h], inst), append(wffsG, inst)
+		}
+	}
+
+	return
+}
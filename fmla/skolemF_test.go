@@ -0,0 +1,89 @@
+package fmla
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestSkolemize(t *testing.T) {
+	type testCase struct {
+		s        string
+		expected string
+	}
+
+	var (
+		tcs []testCase
+		tc  testCase
+		wff *WffTree
+		s   string
+		err error
+	)
+
+	tcs = []testCase{
+		{"∃x∀yRxy", "∀yRay"},
+		{"∀x∃yRxy", "∀xRxf(x)"},
+		{"∀x∃y∀z∃wSxyzw", "∀x∀zSxf(x)zg(x,z)"},
+		{"∃xFx∧Ga", "Fb∧Ga"},
+		{"∀x∃y(Rxf(y)∧Fy)", "∀x(Rxf(g(x))∧Fg(x))"},
+		{"∃x∀x(Fx∧¬Fa)", "∀u(Fu∧¬Fa)"},
+	}
+
+	for _, tc = range tcs {
+		if wff, err = ParseWff(tc.s); err != nil {
+			t.Fatalf("\nFAILED: %v", err)
+		}
+
+		if !IsPrenex(wff) {
+			wff = ToPrenex(wff)
+		}
+
+		if s = GetWffString(Skolemize(wff)); s != tc.expected {
+			t.Errorf("\nFAILED: Expected %q to skolemize to %q, got %q.", tc.s, tc.expected, s)
+		} else {
+			t.Logf("\nPASSED: %q skolemizes to %q.", tc.s, s)
+		}
+	}
+}
+
+func TestHerbrandExpansion(t *testing.T) {
+	type testCase struct {
+		s        string
+		acs      []Argument
+		depth    uint
+		expected []string
+	}
+
+	var (
+		tcs  []testCase
+		tc   testCase
+		wff  *WffTree
+		inst *WffTree
+		ss   []string
+		err  error
+	)
+
+	tcs = []testCase{
+		{"∀x(Fx→Gx)", []Argument{'a', 'b'}, 0, []string{"Fa→Ga", "Fb→Gb"}},
+		{"∀x∀yRxy", nil, 0, []string{"Raa"}},
+		{"∀x(Fx→Ff(x))", []Argument{'a'}, 1, []string{"Fa→Ff(a)", "Ff(a)→Ff(f(a))"}},
+		{"∀x(Rxx∨Rxc)", []Argument{'c'}, 0, []string{"Rcc∨Rcc"}},
+	}
+
+	for _, tc = range tcs {
+		if wff, err = ParseWff(tc.s); err != nil {
+			t.Fatalf("\nFAILED: %v", err)
+		}
+
+		ss = []string{}
+
+		for _, inst = range HerbrandExpansion(wff, tc.acs, tc.depth) {
+			ss = append(ss, GetWffString(inst))
+		}
+
+		if !slices.Equal(ss, tc.expected) {
+			t.Errorf("\nFAILED: Expected the expansion of %q to be %q, got %q.", tc.s, tc.expected, ss)
+		} else {
+			t.Logf("\nPASSED: The expansion of %q is %q.", tc.s, ss)
+		}
+	}
+}
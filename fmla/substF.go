@@ -0,0 +1,295 @@
+package fmla
+
+import (
+	"fmt"
+	"hash/fnv"
+	"slices"
+	"strings"
+)
+
+func getTermVariables(t *Term) (avs []Argument) {
+	var (
+		arg Argument
+	)
+
+	for _, arg = range argStringToArgs(termsToArgString(t)) {
+		if IsArgVar(arg) && !slices.Contains(avs, arg) {
+			avs = append(avs, arg)
+		}
+	}
+
+	return
+}
+
+func isFreeArg(wff *WffTree, av Argument) (is bool) {
+	var (
+		avs []Argument
+	)
+
+	_, avs = GetFreeVariables(wff)
+
+	is = slices.Contains(avs, av)
+
+	return
+}
+
+func isFreePred(wff *WffTree, pv Predicate) (is bool) {
+	var (
+		pvs []Predicate
+	)
+
+	pvs, _ = GetFreeVariables(wff)
+
+	is = slices.Contains(pvs, pv)
+
+	return
+}
+
+// SubstituteTerm replaces the free occurrences of the argument variable av in wff with the term t.
+// Where t would fall under a quantifier binding one of its variables,
+// that quantifier's variable is first renamed to one that occurs nowhere in its scope or in t.
+func SubstituteTerm(wff *WffTree, av Argument, t *Term) (wffS *WffTree) {
+	var (
+		tvs     []Argument
+		avs     []Argument
+		aVar    Argument
+		scope   *WffTree
+		n       uint
+		arg     Argument
+		newArgs ArgString
+	)
+
+	if wff == nil || t == nil {
+		panic("Invalid WffTree")
+	}
+
+	switch wff.kind {
+	case Atomic:
+		for _, arg = range argStringToArgs(wff.args) {
+			if arg == av {
+				newArgs += termsToArgString(t)
+			} else {
+				newArgs += ArgString(arg)
+			}
+		}
+
+		wffS = newAtomic(wff.pred, newArgs)
+	case Unary:
+		wffS = NewCompositeWff(wff.mop, SubstituteTerm(wff.subL, av, t), nil, 0, 0)
+	case Binary:
+		wffS = NewCompositeWff(wff.mop, SubstituteTerm(wff.subL, av, t), SubstituteTerm(wff.subR, av, t), 0, 0)
+	case Quantified:
+		if wff.aVar == av || !isFreeArg(wff.subL, av) {
+			wffS = DeepCopy(wff)
+
+			break
+		}
+
+		aVar, scope = wff.aVar, wff.subL
+
+		if tvs = getTermVariables(t); aVar != 0 && slices.Contains(tvs, aVar) {
+			_, avs = GetVariables(scope)
+
+			for aVar = NthArgVar(n); aVar == av || slices.Contains(tvs, aVar) || slices.Contains(avs, aVar); aVar = NthArgVar(n) {
+				n += 1
+			}
+
+			scope = SubstituteTerm(scope, wff.aVar, NewArgTerm(aVar))
+		}
+
+		wffS = NewCompositeWff(wff.mop, SubstituteTerm(scope, av, t), nil, wff.pVar, aVar)
+	default:
+		panic("Invalid WffTree")
+	}
+
+	return
+}
+
+// SubstitutePred replaces the free occurrences of the predicate variable pv in wff with the predicate pred.
+// Where pred is a predicate variable that would fall under a quantifier binding it,
+// that quantifier's variable is first renamed to one that occurs nowhere in its scope.
+func SubstitutePred(wff *WffTree, pv, pred Predicate) (wffS *WffTree) {
+	var (
+		pvs   []Predicate
+		pVar  Predicate
+		scope *WffTree
+		n     uint
+	)
+
+	if wff == nil {
+		panic("Invalid WffTree")
+	}
+
+	switch wff.kind {
+	case Atomic:
+		if wffS = DeepCopy(wff); wffS.pred == pv {
+			wffS = newAtomic(pred, wff.args)
+		}
+	case Unary:
+		wffS = NewCompositeWff(wff.mop, SubstitutePred(wff.subL, pv, pred), nil, 0, 0)
+	case Binary:
+		wffS = NewCompositeWff(wff.mop, SubstitutePred(wff.subL, pv, pred), SubstitutePred(wff.subR, pv, pred), 0, 0)
+	case Quantified:
+		if wff.pVar == pv || !isFreePred(wff.subL, pv) {
+			wffS = DeepCopy(wff)
+
+			break
+		}
+
+		if pVar, scope = wff.pVar, wff.subL; pVar != 0 && pVar == pred {
+			pvs, _ = GetVariables(scope)
+
+			for pVar = NthPredVar(n); pVar == pv || slices.Contains(pvs, pVar); pVar = NthPredVar(n) {
+				n += 1
+			}
+
+			scope = SubstitutePred(scope, wff.pVar, pVar)
+		}
+
+		wffS = NewCompositeWff(wff.mop, SubstitutePred(scope, pv, pred), nil, pVar, wff.aVar)
+	default:
+		panic("Invalid WffTree")
+	}
+
+	return
+}
+
+// writeDeBruijn writes a formula with each bound variable replaced by its de Bruijn index,
+// the number of quantifiers between it and its binder, so that alpha-variants are written alike.
+func writeDeBruijn(sb *strings.Builder, wff *WffTree, bound []rune) {
+	var (
+		arg   Argument
+		write func(r rune)
+	)
+
+	// A variable's binder is the innermost quantifier that binds it, so search the bound variables from the end.
+	write = func(r rune) {
+		var (
+			dex int
+		)
+
+		for dex = len(bound) - 1; -1 < dex && bound[dex] != r; dex -= 1 {
+		}
+
+		if dex == -1 {
+			sb.WriteRune(r)
+		} else {
+			fmt.Fprintf(sb, "#%d.", len(bound)-1-dex)
+		}
+	}
+
+	sb.WriteRune(rune('0' + wff.kind))
+
+	switch wff.kind {
+	case Atomic:
+		write(rune(wff.pred))
+
+		for _, arg = range argStringToArgs(wff.args) {
+			write(rune(arg))
+		}
+	case Unary:
+		sb.WriteRune(rune(wff.mop))
+
+		writeDeBruijn(sb, wff.subL, bound)
+	case Binary:
+		sb.WriteRune(rune(wff.mop))
+
+		writeDeBruijn(sb, wff.subL, bound)
+		writeDeBruijn(sb, wff.subR, bound)
+	case Quantified:
+		sb.WriteRune(rune(wff.mop))
+
+		// Mark what kind of variable is bound, since the index alone doesn't say.
+		if wff.pVar != 0 {
+			sb.WriteRune('P')
+
+			writeDeBruijn(sb, wff.subL, append(slices.Clip(bound), rune(wff.pVar)))
+		} else {
+			sb.WriteRune('A')
+
+			writeDeBruijn(sb, wff.subL, append(slices.Clip(bound), rune(wff.aVar)))
+		}
+	default:
+		panic("Invalid WffTree")
+	}
+
+	sb.WriteRune(rune(RPar))
+}
+
+func deBruijnString(wff *WffTree) (s string) {
+	var (
+		sb strings.Builder
+	)
+
+	writeDeBruijn(&sb, wff, nil)
+
+	s = sb.String()
+
+	return
+}
+
+// An alphaForm is a node's de Bruijn string and its hash. Nodes are interned and never change,
+// so each node computes its alphaForm at most once, however often the prover compares it.
+type alphaForm struct {
+	s string
+	h uint64
+}
+
+func alphaFormOf(wff *WffTree) (af *alphaForm) {
+	var (
+		hash64 = fnv.New64a()
+		ok     bool
+	)
+
+	if af, ok = wff.alpha.Load().(*alphaForm); ok {
+		return
+	}
+
+	af = &alphaForm{s: deBruijnString(wff)}
+
+	hash64.Write([]byte(af.s))
+
+	af.h = hash64.Sum64()
+
+	// Goroutines racing here compute the same alphaForm, so whichever is stored will do.
+	wff.alpha.Store(af)
+
+	return
+}
+
+// AlphaEquivalent reports whether two formulae differ at most in the names of their bound variables,
+// as ∀xFx and ∀yFy do.
+func AlphaEquivalent(wffA, wffB *WffTree) (is bool) {
+	var (
+		afA, afB *alphaForm
+	)
+
+	if wffA == nil || wffB == nil {
+		panic("Invalid WffTree")
+	}
+
+	// Equal formulae share one interned node.
+	if wffA == wffB {
+		is = true
+
+		return
+	}
+
+	afA, afB = alphaFormOf(wffA), alphaFormOf(wffB)
+
+	is = afA.h == afB.h && afA.s == afB.s
+
+	return
+}
+
+// AlphaHash returns a hash of a formula that ignores the names of its bound variables,
+// so that alpha-variants hash alike.
+func AlphaHash(wff *WffTree) (h uint64) {
+	if wff == nil {
+		panic("Invalid WffTree")
+	}
+
+	h = alphaFormOf(wff).h
+
+	return
+}
@@ -0,0 +1,86 @@
+package fmla
+
+import (
+	"testing"
+)
+
+func TestSubstituteTerm(t *testing.T) {
+	type testCase struct {
+		s        string
+		av       Argument
+		t        *Term
+		expected string
+	}
+
+	var (
+		tcs   []testCase
+		tc    testCase
+		wff   *WffTree
+		scope *WffTree
+		s     string
+		err   error
+	)
+
+	// Each case substitutes in the scope of the outermost quantifier, where its variable is free.
+	tcs = []testCase{
+		{"∃x∀yRxy", 'x', NewArgTerm('y'), "∀uRyu"},
+		{"∃x∀yRxy", 'x', NewArgTerm('a'), "∀yRay"},
+		{"∃x(Fx∧∃xGx)", 'x', NewArgTerm('a'), "Fa∧∃xGx"},
+		{"∃x∀y∃u(Rxy∧Fu)", 'x', NewFuncTerm(NewFunction('f', 0, 2), NewArgTerm('y'), NewArgTerm('u')), "∀v∃w(Rf(y,u)v∧Fw)"},
+	}
+
+	for _, tc = range tcs {
+		if wff, err = ParseWff(tc.s); err != nil {
+			t.Fatalf("\nFAILED: %v", err)
+		}
+
+		scope, _ = GetWffSubformulae(wff)
+
+		if s = GetWffString(SubstituteTerm(scope, tc.av, tc.t)); s != tc.expected {
+			t.Errorf("\nFAILED: Expected [%s/%s] in the scope of %q to be %q, got %q.", GetTermString(tc.t), tc.av, tc.s, tc.expected, s)
+		} else {
+			t.Logf("\nPASSED: [%s/%s] in the scope of %q is %q.", GetTermString(tc.t), tc.av, tc.s, s)
+		}
+	}
+
+	wff, _ = ParseWff("∀X∃Y(Xa∧Ya)")
+
+	if s = GetWffString(Instantiate(wff, 'Y', 0)); s != "∃U(Ya∧Ua)" {
+		t.Errorf("\nFAILED: Expected the instance of %q at Y to be %q, got %q.", GetWffString(wff), "∃U(Ya∧Ua)", s)
+	}
+}
+
+func TestAlphaEquivalent(t *testing.T) {
+	type testCase struct {
+		sA, sB string
+		is     bool
+	}
+
+	var (
+		tcs        []testCase
+		tc         testCase
+		wffA, wffB *WffTree
+		is         bool
+	)
+
+	tcs = []testCase{
+		{"∀xFx", "∀yFy", true},
+		{"∀x∀yRxy", "∀y∀xRyx", true},
+		{"∀x∀yRxy", "∀y∀xRxy", false},
+		{"∀x(Fx∧∃xGx)", "∀y(Fy∧∃zGz)", true},
+		{"∀x(Fx∧∃yGx)", "∀y(Fy∧∃zGz)", false},
+		{"∃X∀xXx", "∃Y∀yYy", true},
+		{"∀xFx", "∃xFx", false},
+	}
+
+	for _, tc = range tcs {
+		wffA, _ = ParseWff(tc.sA)
+		wffB, _ = ParseWff(tc.sB)
+
+		if is = AlphaEquivalent(wffA, wffB); is != tc.is || (AlphaHash(wffA) == AlphaHash(wffB)) != tc.is {
+			t.Errorf("\nFAILED: Expected %q and %q to be alpha-equivalent: %t.", tc.sA, tc.sB, tc.is)
+		} else {
+			t.Logf("\nPASSED: %q and %q are alpha-equivalent: %t.", tc.sA, tc.sB, is)
+		}
+	}
+}
@@ -0,0 +1,379 @@
+package fmla
+
+import (
+	"slices"
+	"strings"
+)
+
+// Indexed symbols, like a₁ or F₁₂, extend the alphabets of predicates and arguments
+// past their single letters. Each one is encoded as a single rune in a private use plane,
+// so that Predicate, Argument, and ArgString can still be handled rune by rune.
+// An index of 0 denotes the plain letter itself.
+const (
+	argIdxBase  rune = 0xF0000  // The first indexed argument, a₁.
+	predIdxBase rune = 0x100000 // The first indexed predicate, A₁.
+	metaIdxBase rune = 0x108000 // The first indexed schema letter, α₁.
+	idxStride   rune = 32       // The span of runes between a letter's consecutive indices.
+
+	MaxIndex uint = 1023
+)
+
+var subDigits = []rune("₀₁₂₃₄₅₆₇₈₉")
+
+func NewIndexedArgument(letter rune, idx uint) (arg Argument) {
+	if letter < 'a' || 'z' < letter || MaxIndex < idx {
+		panic("Invalid indexed argument.")
+	}
+
+	if idx == 0 {
+		arg = Argument(letter)
+	} else {
+		arg = Argument(argIdxBase + rune(idx-1)*idxStride + letter - 'a')
+	}
+
+	return
+}
+
+func NewIndexedPredicate(letter rune, idx uint) (pred Predicate) {
+	if letter < 'A' || 'Z' < letter || MaxIndex < idx {
+		panic("Invalid indexed predicate.")
+	}
+
+	if idx == 0 {
+		pred = Predicate(letter)
+	} else {
+		pred = Predicate(predIdxBase + rune(idx-1)*idxStride + letter - 'A')
+	}
+
+	return
+}
+
+// Schema letters are lower-case Greek letters, which stand for any formula or term.
+// They are indexed like other symbols, and may be used as predicates or arguments as they stand for one or the other.
+func NewIndexedMeta(letter rune, idx uint) (r rune) {
+	if letter < 'α' || 'ω' < letter || MaxIndex < idx {
+		panic("Invalid indexed schema letter.")
+	}
+
+	if idx == 0 {
+		r = letter
+	} else {
+		r = metaIdxBase + rune(idx-1)*idxStride + letter - 'α'
+	}
+
+	return
+}
+
+func splitMeta(rM rune) (letter rune, idx uint, ok bool) {
+	var (
+		r rune = rM - metaIdxBase
+	)
+
+	switch {
+	case -1 < r && r < rune(MaxIndex)*idxStride:
+		letter, idx, ok = 'α'+r%idxStride, uint(r/idxStride)+1, true
+	case 'α'-1 < rM && rM < 'ω'+1:
+		letter, idx, ok = rM, 0, true
+	}
+
+	return
+}
+
+func SplitArgument(arg Argument) (letter rune, idx uint) {
+	var (
+		r  rune = rune(arg) - argIdxBase
+		ok bool
+	)
+
+	if letter, idx, ok = splitMeta(rune(arg)); ok {
+		return
+	}
+
+	if -1 < r && r < rune(MaxIndex)*idxStride {
+		letter, idx = 'a'+r%idxStride, uint(r/idxStride)+1
+	} else {
+		letter, idx = rune(arg), 0
+	}
+
+	return
+}
+
+func SplitPredicate(pred Predicate) (letter rune, idx uint) {
+	var (
+		r  rune = rune(pred) - predIdxBase
+		ok bool
+	)
+
+	if letter, idx, ok = splitMeta(rune(pred)); ok {
+		return
+	}
+
+	if -1 < r && r < rune(MaxIndex)*idxStride {
+		letter, idx = 'A'+r%idxStride, uint(r/idxStride)+1
+	} else {
+		letter, idx = rune(pred), 0
+	}
+
+	return
+}
+
+func IsArgConst(arg Argument) (is bool) {
+	var letter rune
+
+	letter, _ = SplitArgument(arg)
+
+	is = 'a'-1 < letter && letter < 't'+1
+
+	return
+}
+
+func IsArgVar(arg Argument) (is bool) {
+	var letter rune
+
+	letter, _ = SplitArgument(arg)
+
+	is = 'u'-1 < letter && letter < 'z'+1
+
+	return
+}
+
+func IsPredConst(pred Predicate) (is bool) {
+	var letter rune
+
+	letter, _ = SplitPredicate(pred)
+
+	is = 'A'-1 < letter && letter < 'T'+1
+
+	return
+}
+
+func IsPredVar(pred Predicate) (is bool) {
+	var letter rune
+
+	letter, _ = SplitPredicate(pred)
+
+	is = 'U'-1 < letter && letter < 'Z'+1
+
+	return
+}
+
+// IsFormulaMeta reports whether a predicate is a schema letter for a formula, one of FormulaMetas with any index.
+func IsFormulaMeta(pred Predicate) (is bool) {
+	var (
+		letter rune
+		ok     bool
+	)
+
+	letter, _, ok = splitMeta(rune(pred))
+
+	is = ok && slices.Contains(FormulaMetas, Predicate(letter))
+
+	return
+}
+
+// IsTermMeta reports whether an argument is a schema letter for a term, one of TermMetas with any index.
+func IsTermMeta(arg Argument) (is bool) {
+	var (
+		letter rune
+		ok     bool
+	)
+
+	letter, _, ok = splitMeta(rune(arg))
+
+	is = ok && slices.Contains(TermMetas, Argument(letter))
+
+	return
+}
+
+// The Nth functions list each alphabet in order: first its plain letters,
+// then the letters with index 1, then the letters with index 2, and so on.
+func NthArgConst(n uint) (arg Argument) {
+	arg = NewIndexedArgument(rune(ArgConsts[n%uint(len(ArgConsts))]), n/uint(len(ArgConsts)))
+
+	return
+}
+
+func NthArgVar(n uint) (arg Argument) {
+	arg = NewIndexedArgument(rune(ArgVars[n%uint(len(ArgVars))]), n/uint(len(ArgVars)))
+
+	return
+}
+
+func NthPredConst(n uint) (pred Predicate) {
+	pred = NewIndexedPredicate(rune(PredConsts[n%uint(len(PredConsts))]), n/uint(len(PredConsts)))
+
+	return
+}
+
+func NthPredVar(n uint) (pred Predicate) {
+	pred = NewIndexedPredicate(rune(PredVars[n%uint(len(PredVars))]), n/uint(len(PredVars)))
+
+	return
+}
+
+func ArgOrdinal(arg Argument) (n uint) {
+	var (
+		letter rune
+		idx    uint
+	)
+
+	letter, idx = SplitArgument(arg)
+
+	switch {
+	case IsArgConst(arg):
+		n = idx*uint(len(ArgConsts)) + uint(letter-'a')
+	case IsArgVar(arg):
+		n = idx*uint(len(ArgVars)) + uint(letter-'u')
+	default:
+		panic("Invalid argument.")
+	}
+
+	return
+}
+
+func PredOrdinal(pred Predicate) (n uint) {
+	var (
+		letter rune
+		idx    uint
+	)
+
+	letter, idx = SplitPredicate(pred)
+
+	switch {
+	case IsPredConst(pred):
+		n = idx*uint(len(PredConsts)) + uint(letter-'A')
+	case IsPredVar(pred):
+		n = idx*uint(len(PredVars)) + uint(letter-'U')
+	default:
+		panic("Invalid predicate.")
+	}
+
+	return
+}
+
+func indexString(idx uint) (s string) {
+	var (
+		sb strings.Builder
+		r  rune
+	)
+
+	if idx == 0 {
+		return
+	}
+
+	for _, r = range []rune(uintToString(idx)) {
+		sb.WriteRune(subDigits[r-'0'])
+	}
+
+	s = sb.String()
+
+	return
+}
+
+func uintToString(n uint) (s string) {
+	for s = string(rune('0' + n%10)); 9 < n; s = string(rune('0'+n%10)) + s {
+		n /= 10
+	}
+
+	return
+}
+
+func (arg Argument) String() (s string) {
+	var (
+		letter rune
+		idx    uint
+	)
+
+	letter, idx = SplitArgument(arg)
+
+	s = string(letter) + indexString(idx)
+
+	return
+}
+
+func (pred Predicate) String() (s string) {
+	var (
+		letter rune
+		idx    uint
+	)
+
+	letter, idx = SplitPredicate(pred)
+
+	s = string(letter) + indexString(idx)
+
+	return
+}
+
+func (s ArgString) String() (sS string) {
+	var (
+		arg Argument
+	)
+
+	for _, arg = range argStringToArgs(s) {
+		sS += arg.String()
+	}
+
+	return
+}
+
+func digitValue(sym Symbol) (d uint, ok bool) {
+	switch {
+	case '0'-1 < sym && sym < '9'+1:
+		d, ok = uint(sym-'0'), true
+	case subDigits[0]-1 < rune(sym) && rune(sym) < subDigits[9]+1:
+		d, ok = uint(rune(sym)-subDigits[0]), true
+	}
+
+	return
+}
+
+// foldIndexedSymbols folds each letter and the index digits after it into one indexed symbol.
+// It also returns the offset of the first digit that cannot belong to an index, if any.
+func foldIndexedSymbols(syms []Symbol, offs []int) (symsF []Symbol, offsF []int, offB int) {
+	var (
+		sym             Symbol
+		dex, dexD, lenS int
+		idx, d          uint
+		ok              bool
+	)
+
+	offB, lenS = -1, len(syms)
+
+	for dex = 0; dex < lenS; dex = dexD {
+		sym, idx = syms[dex], 0
+
+		for dexD = dex + 1; dexD < lenS; dexD += 1 {
+			if d, ok = digitValue(syms[dexD]); !ok {
+				break
+			}
+
+			// An index neither starts with 0 nor exceeds MaxIndex.
+			if idx = idx*10 + d; idx == 0 || MaxIndex < idx {
+				offB = offs[dexD]
+
+				return
+			}
+		}
+
+		if dex+1 < dexD {
+			switch {
+			case 'a'-1 < sym && sym < 'z'+1:
+				sym = Symbol(NewIndexedArgument(rune(sym), idx))
+			case 'A'-1 < sym && sym < 'Z'+1:
+				sym = Symbol(NewIndexedPredicate(rune(sym), idx))
+			case 'α'-1 < sym && sym < 'ω'+1:
+				sym = Symbol(NewIndexedMeta(rune(sym), idx))
+			default:
+				offB = offs[dex+1]
+
+				return
+			}
+		}
+
+		symsF = append(symsF, sym)
+
+		offsF = append(offsF, offs[dex])
+	}
+
+	return
+}
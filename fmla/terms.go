@@ -0,0 +1,342 @@
+package fmla
+
+import (
+	"strings"
+)
+
+type Function rune
+
+// A Term is an argument constant or variable, or a function symbol applied to
+// as many terms as its arity. Within a WffTree, terms are flattened into the
+// ArgString in prefix notation, which the fixed arities keep unambiguous:
+// g(f(a),x) is stored as the three runes g, f, a, and x.
+type Term struct {
+	fun  Function // If non-zero, the function symbol applied to subs.
+	arg  Argument // If fun is zero, the argument constant or variable.
+	subs []*Term  // The terms to which fun is applied.
+}
+
+// Function symbols are named by the letters of the argument constants,
+// with an optional index, and are encoded like indexed symbols.
+const (
+	funcIdxBase rune = 0xF8000 // The first function symbol, a of arity 0 and index 0, which is unused.
+	arityStride rune = 8       // The span of arities for each index of a letter.
+
+	MaxArity     uint = 7
+	MaxFuncIndex uint = 127
+)
+
+func NewFunction(letter rune, idx uint, arity uint) (fun Function) {
+	if letter < 'a' || 't' < letter || MaxFuncIndex < idx || arity == 0 || MaxArity < arity {
+		panic("Invalid function symbol.")
+	}
+
+	fun = Function(funcIdxBase + (rune(idx)*arityStride+rune(arity))*idxStride + letter - 'a')
+
+	return
+}
+
+func IsFunction(fun Function) (is bool) {
+	var (
+		r rune = rune(fun) - funcIdxBase
+	)
+
+	is = -1 < r && r < (rune(MaxFuncIndex)+1)*arityStride*idxStride &&
+		r%idxStride < rune(len(ArgConsts)) && (r/idxStride)%arityStride != 0
+
+	return
+}
+
+func SplitFunction(fun Function) (letter rune, idx uint, arity uint) {
+	var (
+		r rune = rune(fun) - funcIdxBase
+	)
+
+	if !IsFunction(fun) {
+		panic("Invalid function symbol.")
+	}
+
+	letter = 'a' + r%idxStride
+
+	r /= idxStride
+
+	idx, arity = uint(r/arityStride), uint(r%arityStride)
+
+	return
+}
+
+func (fun Function) String() (s string) {
+	var (
+		letter rune
+		idx    uint
+	)
+
+	letter, idx, _ = SplitFunction(fun)
+
+	s = string(letter) + indexString(idx)
+
+	return
+}
+
+func NewArgTerm(arg Argument) (t *Term) {
+	if IsFunction(Function(arg)) {
+		panic("Invalid argument.")
+	}
+
+	t = &Term{
+		arg: arg,
+	}
+
+	return
+}
+
+func NewFuncTerm(fun Function, subs ...*Term) (t *Term) {
+	var (
+		arity uint
+		sub   *Term
+	)
+
+	if _, _, arity = SplitFunction(fun); uint(len(subs)) != arity {
+		panic("Function symbol applied to the wrong number of terms.")
+	}
+
+	t = &Term{
+		fun:  fun,
+		subs: []*Term{},
+	}
+
+	for _, sub = range subs {
+		if sub == nil {
+			panic("Missing term.")
+		}
+
+		t.subs = append(t.subs, sub)
+	}
+
+	return
+}
+
+func GetTermArg(t *Term) (arg Argument, ok bool) {
+	if t == nil {
+		panic("Invalid Term")
+	}
+
+	if ok = t.fun == 0; ok {
+		arg = t.arg
+	}
+
+	return
+}
+
+func GetTermFunc(t *Term) (fun Function, subs []*Term, ok bool) {
+	if t == nil {
+		panic("Invalid Term")
+	}
+
+	if ok = t.fun != 0; ok {
+		fun = t.fun
+		subs = append(subs, t.subs...)
+	}
+
+	return
+}
+
+func GetTermString(t *Term) (s string) {
+	var (
+		ss  []string
+		sub *Term
+	)
+
+	if t.fun == 0 {
+		s = t.arg.String()
+
+		return
+	}
+
+	for _, sub = range t.subs {
+		ss = append(ss, GetTermString(sub))
+	}
+
+	s = t.fun.String() + string(LPar) + strings.Join(ss, string(Comma)) + string(RPar)
+
+	return
+}
+
+func IsIdenticalTerm(tA, tB *Term) (is bool) {
+	is = termsToArgString(tA) == termsToArgString(tB)
+
+	return
+}
+
+func IsGroundTerm(t *Term) (is bool) {
+	var (
+		sub *Term
+	)
+
+	if t.fun == 0 {
+		is = !IsArgVar(t.arg)
+
+		return
+	}
+
+	is = true
+
+	for _, sub = range t.subs {
+		if is = IsGroundTerm(sub); !is {
+			break
+		}
+	}
+
+	return
+}
+
+func termsToArgString(ts ...*Term) (s ArgString) {
+	var (
+		t *Term
+	)
+
+	for _, t = range ts {
+		if t.fun == 0 {
+			s += ArgString(t.arg)
+		} else {
+			s += ArgString(t.fun) + termsToArgString(t.subs...)
+		}
+	}
+
+	return
+}
+
+func decodeTerm(args []Argument, dex int) (t *Term, next int) {
+	var (
+		arity, n uint
+		sub      *Term
+	)
+
+	if len(args) <= dex {
+		panic("Truncated ArgString.")
+	}
+
+	if !IsFunction(Function(args[dex])) {
+		t, next = NewArgTerm(args[dex]), dex+1
+
+		return
+	}
+
+	_, _, arity = SplitFunction(Function(args[dex]))
+
+	t = &Term{
+		fun:  Function(args[dex]),
+		subs: []*Term{},
+	}
+
+	for n, next = 0, dex+1; n < arity; n += 1 {
+		sub, next = decodeTerm(args, next)
+
+		t.subs = append(t.subs, sub)
+	}
+
+	return
+}
+
+func argStringToTerms(s ArgString) (ts []*Term) {
+	var (
+		args      []Argument
+		dex, lenA int
+		t         *Term
+	)
+
+	args = argStringToArgs(s)
+
+	for dex, lenA = 0, len(args); dex < lenA; {
+		t, dex = decodeTerm(args, dex)
+
+		ts = append(ts, t)
+	}
+
+	return
+}
+
+func GetWffPredAndTerms(wff *WffTree) (pred Predicate, ts []*Term, ok bool) {
+	if wff == nil {
+		panic("Invalid WffTree")
+	}
+
+	if ok = wff.kind == Atomic; ok {
+		pred = wff.pred
+		ts = argStringToTerms(wff.args)
+	}
+
+	return
+}
+
+func collectGroundTerms(t *Term, seen map[ArgString]bool) (ts []*Term) {
+	var (
+		sub *Term
+		s   ArgString
+	)
+
+	if t.fun == 0 {
+		return
+	}
+
+	if s = termsToArgString(t); IsGroundTerm(t) && !seen[s] {
+		seen[s] = true
+
+		ts = append(ts, t)
+	}
+
+	for _, sub = range t.subs {
+		ts = append(ts, collectGroundTerms(sub, seen)...)
+	}
+
+	return
+}
+
+// GetGroundTerms returns every function term in wff without variables,
+// including those nested in other terms, each only once.
+func GetGroundTerms(wff *WffTree) (ts []*Term) {
+	var (
+		seen  map[ArgString]bool
+		atoms []*WffTree
+		atom  *WffTree
+		t     *Term
+	)
+
+	seen = map[ArgString]bool{}
+
+	atoms = orderAtomics(wff)
+
+	for _, atom = range atoms {
+		for _, t = range argStringToTerms(atom.args) {
+			ts = append(ts, collectGroundTerms(t, seen)...)
+		}
+	}
+
+	return
+}
+
+func HasTerm(wff *WffTree, t *Term) (has bool) {
+	var (
+		atoms []*WffTree
+		atom  *WffTree
+		s     ArgString
+	)
+
+	if wff == nil {
+		panic("Invalid WffTree")
+	}
+
+	// Every position in a prefix-notation ArgString begins a subterm,
+	// so any occurrence of the encoded term is an occurrence of the term.
+	s = termsToArgString(t)
+
+	atoms = orderAtomics(wff)
+
+	for _, atom = range atoms {
+		if has = strings.Contains(string(atom.args), string(s)); has {
+			break
+		}
+	}
+
+	return
+}
@@ -0,0 +1,101 @@
+package fmla
+
+import (
+	"testing"
+)
+
+func TestReplaceEachTermOnce(t *testing.T) {
+	type testCase struct {
+		s, sA, sB string
+		exps      []string
+	}
+
+	var (
+		tcs       []testCase
+		tc        testCase
+		wff, wffB *WffTree
+		tA, tB    *Term
+		wffsR     []*WffTree
+		dex       int
+		err       error
+		wffE      *WffTree
+		getTerm   func(s string) (tm *Term)
+	)
+
+	// The terms are read off the left-hand sides of identities.
+	getTerm = func(s string) (tm *Term) {
+		var ts []*Term
+
+		if wffB, err = ParseWff(s + "=a"); err != nil {
+			t.Fatalf("\nFAILED: Failed to parse %q: %v.", s, err)
+		}
+
+		_, ts, _ = GetWffPredAndTerms(wffB)
+
+		tm = ts[0]
+
+		return
+	}
+
+	tcs = []testCase{
+		{"Ff(a)", "f(a)", "b", []string{"Fb"}},
+		{"Rf(a)f(a)", "f(a)", "b", []string{"Rbf(a)", "Rf(a)b"}},
+		{"Fg(f(a),a)", "a", "c", []string{"Fg(f(c),a)", "Fg(f(a),c)"}},
+		{"Ff(a)∧Gf(f(a))", "f(a)", "b", []string{"Fb∧Gf(f(a))", "Ff(a)∧Gf(b)"}},
+		{"Fa", "f(a)", "b", nil},
+	}
+
+	for _, tc = range tcs {
+		if wff, err = ParseWff(tc.s); err != nil {
+			t.Errorf("\nFAILED: Failed to parse %q: %v.", tc.s, err)
+
+			break
+		}
+
+		tA, tB = getTerm(tc.sA), getTerm(tc.sB)
+
+		if wffsR = ReplaceEachTermOnce(wff, tA, tB); len(wffsR) != len(tc.exps) {
+			t.Errorf("\nFAILED: Expected %d replacements in %q, got %d.", len(tc.exps), tc.s, len(wffsR))
+
+			break
+		}
+
+		for dex, wffE = range wffsR {
+			if GetWffString(wffE) != tc.exps[dex] {
+				t.Errorf("\nFAILED: Expected %q from %q, got %q.", tc.exps[dex], tc.s, GetWffString(wffE))
+
+				return
+			}
+		}
+
+		t.Logf("\nPASSED: Replaced %q with %q once each in %q.", tc.sA, tc.sB, tc.s)
+	}
+}
+
+func TestInstantiateTerm(t *testing.T) {
+	var (
+		wff, wffI, wffF *WffTree
+		ts              []*Term
+		err             error
+	)
+
+	if wff, err = ParseWff("∀x(Fx→Gf(x))"); err != nil {
+		t.Fatalf("\nFAILED: %v", err)
+	}
+
+	if wffF, err = ParseWff("g(a,b)=a"); err != nil {
+		t.Fatalf("\nFAILED: %v", err)
+	}
+
+	_, ts, _ = GetWffPredAndTerms(wffF)
+
+	if wffI = InstantiateTerm(wff, ts[0]); GetWffString(wffI) != "Fg(a,b)→Gf(g(a,b))" {
+		t.Errorf("\nFAILED: Expected %q, got %q.", "Fg(a,b)→Gf(g(a,b))", GetWffString(wffI))
+	} else {
+		t.Logf("\nPASSED: Instantiated %q as %q.", GetWffString(wff), GetWffString(wffI))
+	}
+
+	if ts = GetGroundTerms(wffI); len(ts) != 2 {
+		t.Errorf("\nFAILED: Expected 2 ground terms, got %d.", len(ts))
+	}
+}
@@ -0,0 +1,147 @@
+package fmla
+
+import (
+	"slices"
+)
+
+// A Literal is a variable, numbered from 1, or its negation, as in DIMACS.
+type Literal int
+
+// A Clause is a disjunction of literals.
+type Clause []Literal
+
+// A ClauseSet is a conjunction of clauses over variables 1 through NumVars.
+type ClauseSet struct {
+	Clauses []Clause
+	NumVars int
+	Atoms   []*WffTree // The atomic formula of each variable v at Atoms[v-1], or nil for one that Tseitin added.
+}
+
+func (lit Literal) Var() (v int) {
+	if v = int(lit); v < 0 {
+		v = -v
+	}
+
+	return
+}
+
+// tseitinEncoder gives a variable to each distinct atomic formula and compound subformula.
+type tseitinEncoder struct {
+	cs   *ClauseSet
+	wffs map[uint64][]*WffTree // The subformulae encoded so far, by hash.
+	vars map[uint64][]Literal  // The literal of each formula in wffs, at the same place.
+}
+
+func (enc *tseitinEncoder) newVar(atom *WffTree) (lit Literal) {
+	enc.cs.NumVars += 1
+
+	lit = Literal(enc.cs.NumVars)
+
+	enc.cs.Atoms = append(enc.cs.Atoms, atom)
+
+	return
+}
+
+func (enc *tseitinEncoder) add(lits ...Literal) {
+	enc.cs.Clauses = append(enc.cs.Clauses, Clause(lits))
+}
+
+// encode returns the literal that stands for wff, adding the clauses that define it the first time.
+func (enc *tseitinEncoder) encode(wff *WffTree) (lit Literal) {
+	var (
+		litL, litR Literal
+		dex        int
+	)
+
+	if dex = slices.IndexFunc(enc.wffs[wff.h], func(wffE *WffTree) (is bool) {
+		is = Equal(wffE, wff)
+
+		return
+	}); dex != -1 {
+		lit = enc.vars[wff.h][dex]
+
+		return
+	}
+
+	switch wff.kind {
+	case Atomic:
+		switch wff.pred {
+		case Top:
+			lit = enc.newVar(nil)
+
+			enc.add(lit)
+		case Bot:
+			lit = enc.newVar(nil)
+
+			enc.add(-lit)
+		default:
+			lit = enc.newVar(DeepCopy(wff))
+		}
+	case Unary:
+		if wff.mop != Neg {
+			panic("WffTree is modal.")
+		}
+
+		lit = -enc.encode(wff.subL)
+	case Binary:
+		litL, litR = enc.encode(wff.subL), enc.encode(wff.subR)
+
+		lit = enc.newVar(nil)
+
+		switch wff.mop {
+		case Wedge:
+			enc.add(-lit, litL)
+			enc.add(-lit, litR)
+			enc.add(lit, -litL, -litR)
+		case Vee:
+			enc.add(-lit, litL, litR)
+			enc.add(lit, -litL)
+			enc.add(lit, -litR)
+		case To:
+			enc.add(-lit, -litL, litR)
+			enc.add(lit, litL)
+			enc.add(lit, -litR)
+		case Iff:
+			enc.add(-lit, -litL, litR)
+			enc.add(-lit, litL, -litR)
+			enc.add(lit, litL, litR)
+			enc.add(lit, -litL, -litR)
+		}
+	default:
+		panic("WffTree is quantified.")
+	}
+
+	enc.wffs[wff.h], enc.vars[wff.h] = append(enc.wffs[wff.h], wff), append(enc.vars[wff.h], lit)
+
+	return
+}
+
+// Tseitin returns clauses that are satisfiable just when the conjunction of the formulae is,
+// with a variable for each distinct atomic formula and one more for each compound subformula,
+// so that the clauses grow only linearly with the formulae, unlike their CNF.
+// A model of the clauses restricts to a model of the formulae on the atoms.
+// The formulae must be free of quantifiers and modal operators, though their atoms needn't be 0-place.
+func Tseitin(wffs ...*WffTree) (cs *ClauseSet) {
+	var (
+		enc *tseitinEncoder
+		wff *WffTree
+	)
+
+	enc = &tseitinEncoder{
+		cs:   &ClauseSet{},
+		wffs: map[uint64][]*WffTree{},
+		vars: map[uint64][]Literal{},
+	}
+
+	for _, wff = range wffs {
+		if wff == nil {
+			panic("Invalid WffTree")
+		}
+
+		enc.add(enc.encode(wff))
+	}
+
+	cs = enc.cs
+
+	return
+}
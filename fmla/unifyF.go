@@ -0,0 +1,407 @@
+package fmla
+
+import (
+	"slices"
+	"strings"
+)
+
+// A Substitution says what schema letters stand for: each formula letter a formula and each term letter a term.
+type Substitution struct {
+	Wffs  map[Predicate]*WffTree
+	Terms map[Argument]*Term
+}
+
+func NewSubstitution() (sub *Substitution) {
+	sub = &Substitution{
+		Wffs:  map[Predicate]*WffTree{},
+		Terms: map[Argument]*Term{},
+	}
+
+	return
+}
+
+// String writes the bindings in the order of their letters, as in {φ ↦ P∧Q, τ ↦ f(a)}.
+func (sub *Substitution) String() (s string) {
+	var (
+		ss   []string
+		pred Predicate
+		arg  Argument
+		keys []Predicate
+		args []Argument
+	)
+
+	for pred = range sub.Wffs {
+		keys = append(keys, pred)
+	}
+
+	for arg = range sub.Terms {
+		args = append(args, arg)
+	}
+
+	slices.Sort(keys)
+	slices.Sort(args)
+
+	for _, pred = range keys {
+		ss = append(ss, pred.String()+" ↦ "+GetWffString(sub.Wffs[pred]))
+	}
+
+	for _, arg = range args {
+		ss = append(ss, arg.String()+" ↦ "+GetTermString(sub.Terms[arg]))
+	}
+
+	s = "{" + strings.Join(ss, ", ") + "}"
+
+	return
+}
+
+func isMetaWff(wff *WffTree) (is bool) {
+	is = wff.kind == Atomic && IsFormulaMeta(wff.pred)
+
+	return
+}
+
+func isMetaTerm(t *Term) (is bool) {
+	is = t.fun == 0 && IsTermMeta(t.arg)
+
+	return
+}
+
+// applyTerm replaces the bound term letters in t, and, if deep, those in what they are bound to.
+func (sub *Substitution) applyTerm(t *Term, deep bool) (tS *Term) {
+	var (
+		tB   *Term
+		ok   bool
+		subT *Term
+		subs []*Term
+	)
+
+	switch {
+	case isMetaTerm(t):
+		if tB, ok = sub.Terms[t.arg]; !ok {
+			tS = t
+		} else if deep {
+			tS = sub.applyTerm(tB, deep)
+		} else {
+			tS = tB
+		}
+	case t.fun == 0:
+		tS = t
+	default:
+		for _, subT = range t.subs {
+			subs = append(subs, sub.applyTerm(subT, deep))
+		}
+
+		tS = NewFuncTerm(t.fun, subs...)
+	}
+
+	return
+}
+
+func (sub *Substitution) apply(wff *WffTree, deep bool) (wffS *WffTree) {
+	var (
+		wffB *WffTree
+		ok   bool
+		t    *Term
+		ts   []*Term
+	)
+
+	switch wff.kind {
+	case Atomic:
+		if wffB, ok = sub.Wffs[wff.pred]; ok && isMetaWff(wff) {
+			if wffS = wffB; deep {
+				wffS = sub.apply(wffB, deep)
+			}
+
+			return
+		}
+
+		for _, t = range argStringToTerms(wff.args) {
+			ts = append(ts, sub.applyTerm(t, deep))
+		}
+
+		wffS = newAtomic(wff.pred, termsToArgString(ts...))
+	case Unary, Quantified:
+		wffS = rebuild(wff, sub.apply(wff.subL, deep), nil)
+	case Binary:
+		wffS = rebuild(wff, sub.apply(wff.subL, deep), sub.apply(wff.subR, deep))
+	default:
+		panic("Invalid WffTree")
+	}
+
+	return
+}
+
+// Apply puts what each bound schema letter stands for in its place throughout wff.
+// Like any schema instance, it is purely syntactic, so a variable in a term may fall under a quantifier.
+func (sub *Substitution) Apply(wff *WffTree) (wffS *WffTree) {
+	if wff == nil {
+		panic("Invalid WffTree")
+	}
+
+	wffS = sub.apply(DeepCopy(wff), false)
+
+	return
+}
+
+func (sub *Substitution) ApplyTerm(t *Term) (tS *Term) {
+	if t == nil {
+		panic("Missing term.")
+	}
+
+	tS = sub.applyTerm(t, false)
+
+	return
+}
+
+// sameNode reports whether two nodes agree apart from their subformulae.
+func sameNode(wffA, wffB *WffTree) (is bool) {
+	is = wffA.kind == wffB.kind && wffA.mop == wffB.mop && wffA.pVar == wffB.pVar &&
+		wffA.aVar == wffB.aVar && wffA.pred == wffB.pred
+
+	return
+}
+
+func (sub *Substitution) matchTerm(p, t *Term) (ok bool) {
+	var (
+		tB  *Term
+		dex int
+	)
+
+	switch {
+	case isMetaTerm(p):
+		if tB, ok = sub.Terms[p.arg]; ok {
+			ok = IsIdenticalTerm(tB, t)
+		} else {
+			sub.Terms[p.arg], ok = t, true
+		}
+	case p.fun == 0:
+		ok = t.fun == 0 && p.arg == t.arg
+	default:
+		if ok = p.fun == t.fun; !ok {
+			return
+		}
+
+		for dex = 0; ok && dex < len(p.subs); dex += 1 {
+			ok = sub.matchTerm(p.subs[dex], t.subs[dex])
+		}
+	}
+
+	return
+}
+
+func (sub *Substitution) matchTerms(psA, tsA ArgString) (ok bool) {
+	var (
+		ps, ts []*Term
+		dex    int
+	)
+
+	if ps, ts = argStringToTerms(psA), argStringToTerms(tsA); len(ps) != len(ts) {
+		return
+	}
+
+	for ok = true; ok && dex < len(ps); dex += 1 {
+		ok = sub.matchTerm(ps[dex], ts[dex])
+	}
+
+	return
+}
+
+func (sub *Substitution) match(p, wff *WffTree) (ok bool) {
+	var (
+		wffB *WffTree
+	)
+
+	switch {
+	case isMetaWff(p):
+		if wffB, ok = sub.Wffs[p.pred]; ok {
+			ok = wffB == wff
+		} else {
+			sub.Wffs[p.pred], ok = wff, true
+		}
+	case !sameNode(p, wff):
+	case p.kind == Atomic:
+		ok = sub.matchTerms(p.args, wff.args)
+	case p.kind == Binary:
+		ok = sub.match(p.subL, wff.subL) && sub.match(p.subR, wff.subR)
+	default:
+		ok = sub.match(p.subL, wff.subL)
+	}
+
+	return
+}
+
+// Match finds the substitution for the schema letters of a pattern that makes it wff, if there is one.
+// The schema letters of wff, if any, are taken as they stand.
+func Match(pattern, wff *WffTree) (sub *Substitution, ok bool) {
+	if pattern == nil || wff == nil {
+		panic("Invalid WffTree")
+	}
+
+	if sub = NewSubstitution(); !sub.match(DeepCopy(pattern), DeepCopy(wff)) {
+		sub = nil
+
+		return
+	}
+
+	ok = true
+
+	return
+}
+
+// walkWff and walkTerm follow the bindings of schema letters until they reach something unbound.
+func (sub *Substitution) walkWff(wff *WffTree) (wffW *WffTree) {
+	var (
+		ok bool
+	)
+
+	for wffW = wff; isMetaWff(wffW); {
+		if wff, ok = sub.Wffs[wffW.pred]; !ok {
+			break
+		}
+
+		wffW = wff
+	}
+
+	return
+}
+
+func (sub *Substitution) walkTerm(t *Term) (tW *Term) {
+	var (
+		ok bool
+	)
+
+	for tW = t; isMetaTerm(tW); {
+		if t, ok = sub.Terms[tW.arg]; !ok {
+			break
+		}
+
+		tW = t
+	}
+
+	return
+}
+
+func (sub *Substitution) occursWff(pred Predicate, wff *WffTree) (occurs bool) {
+	switch wff = sub.walkWff(wff); {
+	case isMetaWff(wff):
+		occurs = wff.pred == pred
+	case wff.kind == Atomic:
+	case wff.kind == Binary:
+		occurs = sub.occursWff(pred, wff.subL) || sub.occursWff(pred, wff.subR)
+	default:
+		occurs = sub.occursWff(pred, wff.subL)
+	}
+
+	return
+}
+
+func (sub *Substitution) occursTerm(arg Argument, t *Term) (occurs bool) {
+	switch t = sub.walkTerm(t); {
+	case t.fun == 0:
+		occurs = t.arg == arg
+	default:
+		occurs = slices.ContainsFunc(t.subs, func(subT *Term) (occ bool) {
+			occ = sub.occursTerm(arg, subT)
+
+			return
+		})
+	}
+
+	return
+}
+
+func (sub *Substitution) unifyTerm(tA, tB *Term) (ok bool) {
+	var (
+		dex int
+	)
+
+	tA, tB = sub.walkTerm(tA), sub.walkTerm(tB)
+
+	switch {
+	case tA.fun == 0 && tB.fun == 0 && tA.arg == tB.arg:
+		ok = true
+	case isMetaTerm(tA):
+		if ok = !sub.occursTerm(tA.arg, tB); ok {
+			sub.Terms[tA.arg] = tB
+		}
+	case isMetaTerm(tB):
+		if ok = !sub.occursTerm(tB.arg, tA); ok {
+			sub.Terms[tB.arg] = tA
+		}
+	case tA.fun != 0 && tA.fun == tB.fun:
+		for ok = true; ok && dex < len(tA.subs); dex += 1 {
+			ok = sub.unifyTerm(tA.subs[dex], tB.subs[dex])
+		}
+	}
+
+	return
+}
+
+func (sub *Substitution) unify(wffA, wffB *WffTree) (ok bool) {
+	var (
+		tsA, tsB []*Term
+		dex      int
+	)
+
+	wffA, wffB = sub.walkWff(wffA), sub.walkWff(wffB)
+
+	switch {
+	case wffA == wffB:
+		ok = true
+	case isMetaWff(wffA):
+		if ok = !sub.occursWff(wffA.pred, wffB); ok {
+			sub.Wffs[wffA.pred] = wffB
+		}
+	case isMetaWff(wffB):
+		if ok = !sub.occursWff(wffB.pred, wffA); ok {
+			sub.Wffs[wffB.pred] = wffA
+		}
+	case !sameNode(wffA, wffB):
+	case wffA.kind == Atomic:
+		if tsA, tsB = argStringToTerms(wffA.args), argStringToTerms(wffB.args); len(tsA) != len(tsB) {
+			return
+		}
+
+		for ok = true; ok && dex < len(tsA); dex += 1 {
+			ok = sub.unifyTerm(tsA[dex], tsB[dex])
+		}
+	case wffA.kind == Binary:
+		ok = sub.unify(wffA.subL, wffB.subL) && sub.unify(wffA.subR, wffB.subR)
+	default:
+		ok = sub.unify(wffA.subL, wffB.subL)
+	}
+
+	return
+}
+
+// Unify finds the most general substitution for the schema letters of both formulae that makes them the same, if there is one.
+// Each letter is bound to something free of the letters bound, so applying the substitution once is enough.
+func Unify(wffA, wffB *WffTree) (sub *Substitution, ok bool) {
+	var (
+		pred Predicate
+		arg  Argument
+	)
+
+	if wffA == nil || wffB == nil {
+		panic("Invalid WffTree")
+	}
+
+	if sub = NewSubstitution(); !sub.unify(DeepCopy(wffA), DeepCopy(wffB)) {
+		sub = nil
+
+		return
+	}
+
+	// The bindings were made one at a time, so resolve each through the others.
+	for pred = range sub.Wffs {
+		sub.Wffs[pred] = sub.apply(sub.Wffs[pred], true)
+	}
+
+	for arg = range sub.Terms {
+		sub.Terms[arg] = sub.applyTerm(sub.Terms[arg], true)
+	}
+
+	ok = true
+
+	return
+}
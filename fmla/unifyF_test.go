@@ -0,0 +1,101 @@
+package fmla
+
+import (
+	"testing"
+)
+
+func TestMatch(t *testing.T) {
+	type testCase struct {
+		pattern, s string
+		expected   string
+		ok         bool
+	}
+
+	var (
+		tcs          []testCase
+		tc           testCase
+		pattern, wff *WffTree
+		sub          *Substitution
+		ok           bool
+		err          error
+	)
+
+	tcs = []testCase{
+		{"φ→(ψ→φ)", "P→((Q∧R)→P)", "{φ ↦ P, ψ ↦ Q∧R}", true},
+		{"φ→(ψ→φ)", "P→(Q→R)", "", false},
+		{"∀xFx→Fτ", "∀xFx→Ff(a)", "{τ ↦ f(a)}", true},
+		{"Rτf(σ)", "Rbf(g(a,c))", "{σ ↦ g(a,c), τ ↦ b}", true},
+		{"□(φ→ψ)→(□φ→□ψ)", "□(P→⊥)→(□P→□⊥)", "{φ ↦ P, ψ ↦ ⊥}", true},
+		{"Fτ", "Ga", "", false},
+	}
+
+	for _, tc = range tcs {
+		if pattern, err = ParseSchema(tc.pattern); err != nil {
+			t.Fatalf("\nFAILED: %v", err)
+		}
+
+		if wff, err = ParseWff(tc.s); err != nil {
+			t.Fatalf("\nFAILED: %v", err)
+		}
+
+		switch sub, ok = Match(pattern, wff); {
+		case ok != tc.ok:
+			t.Errorf("\nFAILED: Expected matching %q against %q to be %t.", tc.pattern, tc.s, tc.ok)
+		case ok && sub.String() != tc.expected:
+			t.Errorf("\nFAILED: Expected %s, got %s.", tc.expected, sub)
+		case ok && sub.Apply(pattern) != wff:
+			t.Errorf("\nFAILED: Expected %s to turn %q into %q.", sub, tc.pattern, tc.s)
+		default:
+			t.Logf("\nPASSED: Matched %q against %q.", tc.pattern, tc.s)
+		}
+	}
+
+	if _, err = ParseWff("φ→φ"); err == nil {
+		t.Errorf("\nFAILED: Expected schema letters to be refused without ParseSchema.")
+	}
+}
+
+func TestUnify(t *testing.T) {
+	type testCase struct {
+		sA, sB   string
+		expected string
+		ok       bool
+	}
+
+	var (
+		tcs        []testCase
+		tc         testCase
+		wffA, wffB *WffTree
+		sub        *Substitution
+		ok         bool
+		err        error
+	)
+
+	tcs = []testCase{
+		{"φ→(ψ→φ)", "(P∧χ)→(χ→θ)", "{θ ↦ P∧χ, φ ↦ P∧χ, ψ ↦ χ}", true},
+		{"Rτf(τ)", "Rg(σ)f(g(a))", "{σ ↦ a, τ ↦ g(a)}", true},
+		{"φ∧ψ", "ψ∧(φ→P)", "", false},
+		{"Fτ", "Ff(τ)", "", false},
+	}
+
+	for _, tc = range tcs {
+		if wffA, err = ParseSchema(tc.sA); err != nil {
+			t.Fatalf("\nFAILED: %v", err)
+		}
+
+		if wffB, err = ParseSchema(tc.sB); err != nil {
+			t.Fatalf("\nFAILED: %v", err)
+		}
+
+		switch sub, ok = Unify(wffA, wffB); {
+		case ok != tc.ok:
+			t.Errorf("\nFAILED: Expected unifying %q with %q to be %t.", tc.sA, tc.sB, tc.ok)
+		case ok && sub.String() != tc.expected:
+			t.Errorf("\nFAILED: Expected %s, got %s.", tc.expected, sub)
+		case ok && sub.Apply(wffA) != sub.Apply(wffB):
+			t.Errorf("\nFAILED: %s doesn't unify %q and %q.", sub, tc.sA, tc.sB)
+		default:
+			t.Logf("\nPASSED: Unified %q with %q.", tc.sA, tc.sB)
+		}
+	}
+}
@@ -0,0 +1,127 @@
+package fmla
+
+// zipperFrame is one step down from a superformula, into its left or right subformula.
+type zipperFrame struct {
+	sup   *WffTree
+	right bool
+}
+
+// A Zipper focuses on a subformula of a formula and keeps the path back up to the root,
+// since shared nodes have no superformula of their own.
+// Moving and replacing return new Zippers and leave the old ones as they were.
+type Zipper struct {
+	focus *WffTree
+	path  []zipperFrame
+}
+
+// NewZipper returns a Zipper focused on the whole of a formula.
+func NewZipper(wff *WffTree) (z Zipper) {
+	if wff == nil {
+		panic("Invalid WffTree")
+	}
+
+	z = Zipper{focus: DeepCopy(wff)}
+
+	return
+}
+
+func (z Zipper) Focus() (wff *WffTree) {
+	wff = z.focus
+
+	return
+}
+
+// Depth returns the number of steps from the root to the focus.
+func (z Zipper) Depth() (n int) {
+	n = len(z.path)
+
+	return
+}
+
+func (z Zipper) down(sub *WffTree, right bool) (zD Zipper, ok bool) {
+	if sub == nil {
+		return
+	}
+
+	zD, ok = Zipper{
+		focus: sub,
+		path:  append(z.path[:len(z.path):len(z.path)], zipperFrame{z.focus, right}),
+	}, true
+
+	return
+}
+
+// Left moves to the sole subformula of a unary or quantified focus, or the left one of a binary focus.
+func (z Zipper) Left() (zL Zipper, ok bool) {
+	zL, ok = z.down(z.focus.subL, false)
+
+	return
+}
+
+// Right moves to the right subformula of a binary focus.
+func (z Zipper) Right() (zR Zipper, ok bool) {
+	zR, ok = z.down(z.focus.subR, true)
+
+	return
+}
+
+// Up moves to the superformula of the focus, failing at the root.
+func (z Zipper) Up() (zU Zipper, ok bool) {
+	var (
+		fr zipperFrame
+	)
+
+	if len(z.path) == 0 {
+		return
+	}
+
+	fr = z.path[len(z.path)-1]
+
+	zU, ok = Zipper{focus: fr.sup, path: z.path[:len(z.path)-1]}, true
+
+	return
+}
+
+// Replace puts a formula in place of the focus. The superformulae are rebuilt around it as the Zipper moves up.
+func (z Zipper) Replace(wff *WffTree) (zR Zipper) {
+	var (
+		fr   zipperFrame
+		path []zipperFrame
+		sub  *WffTree
+		dex  int
+	)
+
+	if wff == nil {
+		panic("Invalid WffTree")
+	}
+
+	path, sub = make([]zipperFrame, len(z.path)), DeepCopy(wff)
+
+	// Each superformula on the path is rebuilt over the new subformula beneath it.
+	for dex = len(z.path) - 1; -1 < dex; dex -= 1 {
+		fr = z.path[dex]
+
+		path[dex].right = fr.right
+
+		if fr.right {
+			path[dex].sup = rebuild(fr.sup, fr.sup.subL, sub)
+		} else {
+			path[dex].sup = rebuild(fr.sup, sub, fr.sup.subR)
+		}
+
+		sub = path[dex].sup
+	}
+
+	zR = Zipper{focus: DeepCopy(wff), path: path}
+
+	return
+}
+
+// Root returns the whole formula the focus lies in.
+func (z Zipper) Root() (wff *WffTree) {
+	if wff = z.focus; 0 < len(z.path) {
+		wff = z.path[0].sup
+	}
+
+	return
+}
@@ -0,0 +1,115 @@
+package fmla
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestInterning(t *testing.T) {
+	var (
+		wffA, wffB, wffR *WffTree
+		s                string
+		err              error
+		wg               sync.WaitGroup
+		wffsP            []*WffTree
+		dex              int
+	)
+
+	if wffA, err = ParseWff("∀x(Fx→(Gx∧Fx))"); err != nil {
+		t.Fatalf("\nFAILED: %v", err)
+	}
+
+	if wffB, err = ParseWff("∀x(Fx→(Gx∧Fx))"); err != nil {
+		t.Fatalf("\nFAILED: %v", err)
+	}
+
+	if wffA != wffB {
+		t.Errorf("\nFAILED: Expected two parses of %q to share a node.", GetWffString(wffA))
+	}
+
+	// Both occurrences of Fx are one node.
+	if wffA.subL.subL != wffA.subL.subR.subR {
+		t.Errorf("\nFAILED: Expected the occurrences of Fx to share a node.")
+	}
+
+	wffR = ReplacePreds(wffA, 'F', 'H')
+
+	if s = GetWffString(wffA); s != "∀x(Fx→(Gx∧Fx))" {
+		t.Errorf("\nFAILED: ReplacePreds changed its input to %q.", s)
+	}
+
+	if wffR.subL.subR.subL != wffA.subL.subR.subL {
+		t.Errorf("\nFAILED: Expected the unchanged Gx to be shared.")
+	}
+
+	// Goroutines building the same new formula at once still get one node.
+	wffsP = make([]*WffTree, 16)
+
+	for dex = range wffsP {
+		wg.Add(1)
+
+		go func(dex int) {
+			defer wg.Done()
+
+			wffsP[dex], _ = ParseWff("∃y(Hy∨¬(Hy→Gy))")
+		}(dex)
+	}
+
+	wg.Wait()
+
+	for dex = range wffsP {
+		if wffsP[dex] != wffsP[0] {
+			t.Errorf("\nFAILED: Expected the formulae built in parallel to share a node.")
+
+			break
+		}
+	}
+}
+
+func TestZipper(t *testing.T) {
+	var (
+		wff, wffG *WffTree
+		z         Zipper
+		ok        bool
+		s         string
+		err       error
+	)
+
+	if wff, err = ParseWff("(P→(Q∧R))∨¬P"); err != nil {
+		t.Fatalf("\nFAILED: %v", err)
+	}
+
+	z = NewZipper(wff)
+
+	if _, ok = z.Up(); ok {
+		t.Errorf("\nFAILED: Expected no way up from the root.")
+	}
+
+	if z, ok = z.Left(); !ok {
+		t.Fatalf("\nFAILED: Expected a left subformula.")
+	}
+
+	if z, ok = z.Right(); !ok {
+		t.Fatalf("\nFAILED: Expected a right subformula.")
+	}
+
+	if s = GetWffString(z.Focus()); s != "Q∧R" || z.Depth() != 2 {
+		t.Errorf("\nFAILED: Expected Q∧R at depth 2, got %q at depth %d.", s, z.Depth())
+	}
+
+	wffG = NewAtomicWff('S')
+
+	z = z.Replace(wffG)
+
+	if s = GetWffString(z.Root()); s != "(P→S)∨¬P" {
+		t.Errorf("\nFAILED: Expected (P→S)∨¬P, got %q.", s)
+	}
+
+	if z, ok = z.Up(); !ok || GetWffString(z.Focus()) != "P→S" {
+		t.Errorf("\nFAILED: Expected to move up to P→S.")
+	}
+
+	if s = GetWffString(wff); s != "(P→(Q∧R))∨¬P" {
+		t.Errorf("\nFAILED: Replace changed the original formula to %q.", s)
+	}
+}
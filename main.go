@@ -7,14 +7,11 @@ import (
 
 func main() {
 	var (
-		wffs chan *fmla.WffTree
-		wff  *fmla.WffTree
-		s    string
+		wff *fmla.WffTree
+		s   string
 	)
 
-	wffs = fmla.BuildCompositeWffs(2, 2, 2, 2)
-
-	for wff = range wffs {
+	for wff = range fmla.CompositeWffs(2, 2, 2, 2) {
 		s = fmla.GetWffString(fmla.MakeCanonical(wff))
 
 		fmt.Println(s)
@@ -54,6 +54,10 @@ func seedInnerIntroProofs(prf *pr.Proof) (added uint) {
 		av, ac, aac                     fmla.Argument
 	)
 
+	if maxSeedDepth <= prf.GetDepth() {
+		return
+	}
+
 	goals = prf.PopMetSubgoals()
 
 	lenG = len(goals)
@@ -61,7 +65,11 @@ func seedInnerIntroProofs(prf *pr.Proof) (added uint) {
 	for 0 < lenG {
 		goal, goals = goals[0], goals[1:]
 
-		mop, pv, av = fmla.GetWffMopAndVars(goal)
+		mop, pv, av = fmla.GetWffMop(goal), 0, 0
+
+		if fmla.GetWffKind(goal) == fmla.Quantified {
+			_, pv, av = fmla.GetWffMopAndVars(goal)
+		}
 
 		switch mop {
 		case fmla.NoSymbol:
@@ -213,6 +221,10 @@ func seedInnerElimProofs(prf *pr.Proof) (added uint) {
 		ok         bool
 	)
 
+	if maxSeedDepth <= prf.GetDepth() {
+		return
+	}
+
 	lns = prf.GetLocalLines()
 
 	for _, ln = range lns {
@@ -251,20 +263,21 @@ func seedInnerElimProofs(prf *pr.Proof) (added uint) {
 
 			switch {
 			case li.PVar != 0 && apc != 0:
-				wffG = fmla.Instantiate(li.SubL, apc, 0)
-
-				goal = fmla.NewAtomicWff(fmla.Top)
-
-				added += prf.AddUniqueInnerProof(wffG, goal, pr.ExistsElim, ln)
+				wffG = fmla.Instantiate(li.Wff, apc, 0)
 			case li.AVar != 0 && aac != 0:
-				wffG = fmla.Instantiate(li.SubL, 0, aac)
-
-				goal = fmla.NewAtomicWff(fmla.Top)
-
-				added += prf.AddUniqueInnerProof(wffG, goal, pr.ExistsElim, ln)
+				wffG = fmla.Instantiate(li.Wff, 0, aac)
 			default:
 				panic("Invalid WffTree, or missing arbitrary constant.")
 			}
+
+			// A vacuous quantifier leaves its scope as it was, with no arbitrary constant to discharge.
+			if wffG == li.SubL {
+				continue
+			}
+
+			goal = fmla.NewAtomicWff(fmla.Top)
+
+			added += prf.AddUniqueInnerProof(wffG, goal, pr.ExistsElim, ln)
 		case fmla.Diamond:
 			goal = fmla.NewAtomicWff(fmla.Top)
 
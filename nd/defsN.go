@@ -2,6 +2,7 @@ package nd
 
 import (
 	"Deriver/nd/pr"
+	"Deriver/sem"
 )
 
 type ndRuleFunc func(prf *pr.Proof) (added uint)
@@ -9,6 +10,10 @@ type ndRuleFunc func(prf *pr.Proof) (added uint)
 type InferStrength uint
 type ModalStrength uint
 
+// Subproofs are seeded no deeper than maxSeedDepth; otherwise, a failing search
+// would open ever deeper subproofs and never give up.
+const maxSeedDepth uint = 3
+
 const (
 	Implicational InferStrength = iota + 1
 	Positive
@@ -31,6 +36,22 @@ const (
 	SystemKD4B // Proves all the KM4B and KDM4B does.
 )
 
+// The frame properties under which each modal strength is sound and complete.
+// SystemKM includes D's rule, but reflexivity implies seriality anyway.
+var modalStrengthToFrame map[ModalStrength]sem.FrameProperty = map[ModalStrength]sem.FrameProperty{
+	SystemK:    0,
+	SystemKD:   sem.Serial,
+	SystemK4:   sem.Transitive,
+	SystemKB:   sem.Symmetric,
+	SystemKM:   sem.Serial | sem.Reflexive,
+	SystemKD4:  sem.Serial | sem.Transitive,
+	SystemKDB:  sem.Serial | sem.Symmetric,
+	SystemK4B:  sem.Transitive | sem.Symmetric,
+	SystemKM4:  sem.Serial | sem.Reflexive | sem.Transitive,
+	SystemKMB:  sem.Serial | sem.Reflexive | sem.Symmetric,
+	SystemKD4B: sem.Serial | sem.Transitive | sem.Symmetric,
+}
+
 var rulesToFuncs map[pr.NDRule]ndRuleFunc = map[pr.NDRule]ndRuleFunc{
 	pr.TopIntro:     tryTopIntro,
 	pr.ToIntro:      tryToIntro,
@@ -175,6 +196,20 @@ func rulesInModalStrength(modS ModalStrength, infS InferStrength) (iRules, eRule
 	return
 }
 
+// FrameOf returns the frame properties that a Kripke model must have
+// to be a model of the modal strength.
+func FrameOf(modS ModalStrength) (fp sem.FrameProperty) {
+	var (
+		ok bool
+	)
+
+	if fp, ok = modalStrengthToFrame[modS]; !ok {
+		panic("Invalid ModalStrength")
+	}
+
+	return
+}
+
 func incInferStrength(infS InferStrength) (incInfS InferStrength) {
 	incInfS = min(infS+1, Classical)
 
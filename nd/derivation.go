@@ -3,13 +3,16 @@ package nd
 import (
 	"Deriver/fmla"
 	"Deriver/nd/pr"
+	"Deriver/sat"
+	"Deriver/sem"
 )
 
 type Derivation struct {
-	Prf     *pr.Proof
-	InfS    InferStrength
-	ModS    ModalStrength
-	MetGoal bool
+	Prf      *pr.Proof
+	InfS     InferStrength
+	ModS     ModalStrength
+	MetGoal  bool
+	CtrModel *sem.Countermodel // If the goal wasn't met, a model of the premises in which it's false, if one was found.
 }
 
 func pumpIntroductions(prf *pr.Proof, iFuncs []ndRuleFunc) (added uint, met bool) {
@@ -74,15 +77,30 @@ func Derive(goal *fmla.WffTree, prems ...*fmla.WffTree) (drv *Derivation) {
 		iFuncs, eFuncs []ndRuleFunc
 		added          uint
 		met            bool
+		val            sem.Valuation
+		valid          bool
+		err            error
 	)
 
 	infS, modS = Implicational, SystemK
 
 	prf = pr.NewBaseProof(goal, prems...)
 
-	iFuncs, eFuncs = ruleFuncsByStrengths(infS, modS)
+	// 0. If the argument is propositional and a valuation refutes it, no strength of rules will prove it.
+	if val, valid, err = sat.Valid(goal, prems...); err == nil && !valid {
+		drv = &Derivation{
+			Prf:      prf,
+			InfS:     Classical,
+			ModS:     SystemKD4B,
+			CtrModel: &sem.Countermodel{Val: val},
+		}
+
+		return
+	}
 
 	for {
+		iFuncs, eFuncs = ruleFuncsByStrengths(infS, modS)
+
 		// 1. Apply introduction rules until no unique lines are produced or the head goal is met.
 		// 2. If (1) met the head goal, exit! If new, unique lines are produced,
 		//    pop subgoals and return to (1). Otherwise, move to (3).
@@ -133,5 +151,10 @@ func Derive(goal *fmla.WffTree, prems ...*fmla.WffTree) (drv *Derivation) {
 		MetGoal: met,
 	}
 
+	// 8. If even Classical KD4B failed, look for a countermodel to tell an invalid argument from a missed proof.
+	if !met {
+		drv.CtrModel, _ = sem.FindCountermodel(FrameOf(modS), goal, prems...)
+	}
+
 	return
 }
@@ -0,0 +1,130 @@
+package nd
+
+import (
+	"Deriver/fmla"
+	"testing"
+)
+
+func TestDeriveFindsCountermodel(t *testing.T) {
+	type testCase struct {
+		goal  string
+		prems []string
+		modal bool
+	}
+
+	var (
+		tcs   []testCase
+		tc    testCase
+		goal  *fmla.WffTree
+		prems []*fmla.WffTree
+		wff   *fmla.WffTree
+		s     string
+		drv   *Derivation
+		err   error
+	)
+
+	tcs = []testCase{
+		{"∀xFx", []string{"Fa"}, false},
+		{"Fa", []string{"∃xFx"}, false},
+		{"∀y∃xRxy", []string{"∃y∀xRxy"}, false},
+		{"Gb", []string{"∃xGa"}, false},
+		{"□A", []string{"A"}, true},
+		{"A", []string{"◇A"}, true},
+	}
+
+	for _, tc = range tcs {
+		prems = []*fmla.WffTree{}
+
+		for _, s = range tc.prems {
+			if wff, err = fmla.ParseWff(s); err != nil {
+				t.Fatalf("\nFAILED: %v", err)
+			}
+
+			prems = append(prems, wff)
+		}
+
+		if goal, err = fmla.ParseWff(tc.goal); err != nil {
+			t.Fatalf("\nFAILED: %v", err)
+		}
+
+		drv = Derive(goal, prems...)
+
+		switch {
+		case drv.MetGoal:
+			t.Errorf("\nFAILED: Expected %v ⊬ %s, but the goal was met.", tc.prems, tc.goal)
+		case drv.CtrModel == nil:
+			t.Errorf("\nFAILED: Expected a countermodel to %v ⊢ %s, got none.", tc.prems, tc.goal)
+		case tc.modal && drv.CtrModel.Kripke == nil:
+			t.Errorf("\nFAILED: Expected a Kripke model refuting %v ⊢ %s.", tc.prems, tc.goal)
+		case !tc.modal && drv.CtrModel.Interp == nil:
+			t.Errorf("\nFAILED: Expected an interpretation refuting %v ⊢ %s.", tc.prems, tc.goal)
+		default:
+			t.Logf("\nPASSED: Derive refuted %v ⊢ %s.", tc.prems, tc.goal)
+		}
+	}
+}
+
+func TestDeriveMeetsGoal(t *testing.T) {
+	type testCase struct {
+		goal  string
+		prems []string
+	}
+
+	var (
+		tcs   []testCase
+		tc    testCase
+		goal  *fmla.WffTree
+		prems []*fmla.WffTree
+		wff   *fmla.WffTree
+		s     string
+		drv   *Derivation
+		err   error
+	)
+
+	tcs = []testCase{
+		{"A∧B", []string{"B∧A"}},
+		{"P→(Q→P)", nil},
+		{"¬¬P→P", nil},
+		{"∃xFx", []string{"Fa"}},
+		{"□(A∧B)", []string{"□A", "□B"}},
+	}
+
+	for _, tc = range tcs {
+		prems = []*fmla.WffTree{}
+
+		for _, s = range tc.prems {
+			if wff, err = fmla.ParseWff(s); err != nil {
+				t.Fatalf("\nFAILED: %v", err)
+			}
+
+			prems = append(prems, wff)
+		}
+
+		if goal, err = fmla.ParseWff(tc.goal); err != nil {
+			t.Fatalf("\nFAILED: %v", err)
+		}
+
+		if drv = Derive(goal, prems...); !drv.MetGoal {
+			t.Errorf("\nFAILED: Expected %v ⊢ %s to be proven.", tc.prems, tc.goal)
+		} else {
+			t.Logf("\nPASSED: Derive proved %v ⊢ %s.", tc.prems, tc.goal)
+		}
+	}
+}
+
+func TestDeriveForAllElimAvoidsCapture(t *testing.T) {
+	var (
+		goal, prem *fmla.WffTree
+		drv        *Derivation
+	)
+
+	goal, _ = fmla.ParseWff("∃xGa")
+	prem, _ = fmla.ParseWff("∀x(Fx∧∃xGx)")
+
+	// The instance of the premise for a is Fa∧∃xGx; the inner ∃x keeps its own x.
+	if drv = Derive(goal, prem); drv.MetGoal {
+		t.Errorf("\nFAILED: Expected ∀x(Fx∧∃xGx) ⊬ ∃xGa, but the goal was met.")
+	} else {
+		t.Logf("\nPASSED: Derive didn't prove ∃xGa from ∀x(Fx∧∃xGx).")
+	}
+}
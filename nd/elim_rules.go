@@ -20,7 +20,7 @@ var tryToElim ndRuleFunc = func(prf *pr.Proof) (added uint) {
 		}
 
 		for _, j2 = range lns {
-			if j2i = j2.GetLineInfo(); fmla.IsIdentical(j2i.Wff, j1i.SubL) {
+			if j2i = j2.GetLineInfo(); fmla.AlphaEquivalent(j2i.Wff, j1i.SubL) {
 				added += prf.AddUniqueLine(j1i.SubR, pr.ToElim, j1, j2)
 			}
 		}
@@ -65,16 +65,16 @@ var tryVeeElim ndRuleFunc = func(prf *pr.Proof) (added uint) {
 		}
 
 		for _, j2 = range lns {
-			if j2i = j2.GetLineInfo(); !(j2i.Mop == fmla.To && fmla.IsIdentical(j2i.SubL, j1i.SubL)) {
+			if j2i = j2.GetLineInfo(); !(j2i.Mop == fmla.To && fmla.AlphaEquivalent(j2i.SubL, j1i.SubL)) {
 				continue
 			}
 
 			for _, j3 = range lns {
-				if j3i = j3.GetLineInfo(); !(j3i.Mop == fmla.To && fmla.IsIdentical(j3i.SubL, j1i.SubR)) {
+				if j3i = j3.GetLineInfo(); !(j3i.Mop == fmla.To && fmla.AlphaEquivalent(j3i.SubL, j1i.SubR)) {
 					continue
 				}
 
-				if fmla.IsIdentical(j2i.SubR, j3i.SubR) {
+				if fmla.AlphaEquivalent(j2i.SubR, j3i.SubR) {
 					added += prf.AddUniqueLine(j2i.SubR, pr.VeeElim, j1, j2, j3)
 				}
 			}
@@ -125,7 +125,7 @@ var tryBotElim ndRuleFunc = func(prf *pr.Proof) (added uint) {
 	lns = prf.GetLegalLines()
 
 	for _, j1 = range lns {
-		if j1i = j1.GetLineInfo(); !fmla.IsIdentical(j1i.Wff, Fwff) {
+		if j1i = j1.GetLineInfo(); !fmla.AlphaEquivalent(j1i.Wff, Fwff) {
 			continue
 		}
 
@@ -177,8 +177,10 @@ var tryForAllElim ndRuleFunc = func(prf *pr.Proof) (added uint) {
 		wffD *fmla.WffTree
 		pcs  []fmla.Predicate
 		acs  []fmla.Argument
+		ts   []*fmla.Term
 		pc   fmla.Predicate
 		ac   fmla.Argument
+		t    *fmla.Term
 	)
 
 	lns = prf.GetLegalLines()
@@ -193,13 +195,22 @@ var tryForAllElim ndRuleFunc = func(prf *pr.Proof) (added uint) {
 		switch {
 		case j1i.PVar != 0:
 			for _, pc = range pcs {
-				wffD = fmla.ReplacePreds(j1i.SubL, j1i.PVar, pc)
+				wffD = fmla.Instantiate(j1i.Wff, pc, 0)
 
 				added += prf.AddUniqueLine(wffD, pr.ForAllElim, j1)
 			}
 		case j1i.AVar != 0:
 			for _, ac = range acs {
-				wffD = fmla.ReplaceArgs(j1i.SubL, j1i.AVar, ac)
+				wffD = fmla.Instantiate(j1i.Wff, 0, ac)
+
+				added += prf.AddUniqueLine(wffD, pr.ForAllElim, j1)
+			}
+
+			// Only the terms in the goals are used, lest instances feed ever longer terms back in.
+			ts = prf.SelectGoalTerms()
+
+			for _, t = range ts {
+				wffD = fmla.InstantiateTerm(j1i.Wff, t)
 
 				added += prf.AddUniqueLine(wffD, pr.ForAllElim, j1)
 			}
@@ -251,7 +262,7 @@ var tryEqualsElim ndRuleFunc = func(prf *pr.Proof) (added uint) {
 		j1, j2   *pr.Line
 		j1i, j2i *pr.LineInfo
 		pred     fmla.Predicate
-		args     []fmla.Argument
+		ts       []*fmla.Term
 		wffsD    []*fmla.WffTree
 		wffD     *fmla.WffTree
 	)
@@ -263,15 +274,15 @@ var tryEqualsElim ndRuleFunc = func(prf *pr.Proof) (added uint) {
 			continue
 		}
 
-		if pred, args, _ = fmla.GetWffPredAndArgs(j1i.Wff); pred != fmla.Equals || args[0] == args[1] {
+		if pred, ts, _ = fmla.GetWffPredAndTerms(j1i.Wff); pred != fmla.Equals || fmla.IsIdenticalTerm(ts[0], ts[1]) {
 			continue
 		}
 
 		for _, j2 = range lns {
 			j2i = j2.GetLineInfo()
 
-			if fmla.HasArg(j2i.Wff, args[0]) {
-				wffsD = fmla.ReplaceEachArgOnce(j2i.Wff, args[0], args[1])
+			if fmla.HasTerm(j2i.Wff, ts[0]) {
+				wffsD = fmla.ReplaceEachTermOnce(j2i.Wff, ts[0], ts[1])
 
 				for _, wffD = range wffsD {
 					added += prf.AddUniqueLine(wffD, pr.EqualsElim, j1, j2)
@@ -279,8 +290,8 @@ var tryEqualsElim ndRuleFunc = func(prf *pr.Proof) (added uint) {
 
 			}
 
-			if fmla.HasArg(j2i.Wff, args[1]) {
-				wffsD = fmla.ReplaceEachArgOnce(j2i.Wff, args[1], args[0])
+			if fmla.HasTerm(j2i.Wff, ts[1]) {
+				wffsD = fmla.ReplaceEachTermOnce(j2i.Wff, ts[1], ts[0])
 
 				for _, wffD = range wffsD {
 					added += prf.AddUniqueLine(wffD, pr.EqualsElim, j1, j2)
@@ -346,7 +357,7 @@ var tryDiamondElim ndRuleFunc = func(prf *pr.Proof) (added uint) {
 				continue
 			}
 
-			if j3i = j3.GetLineInfo(); fmla.IsIdentical(j3i.Wff, Fwff) {
+			if j3i = j3.GetLineInfo(); fmla.AlphaEquivalent(j3i.Wff, Fwff) {
 				wffD = fmla.NewUnaryChainWff([]fmla.Symbol{fmla.Neg, fmla.Diamond}, j1i.Wff)
 
 				added += prf.AddUniqueLine(wffD, pr.DiamondElim, j1, j2, j3)
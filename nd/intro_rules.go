@@ -67,11 +67,11 @@ var tryWedgeIntro ndRuleFunc = func(prf *pr.Proof) (added uint) {
 		wffDL, wffDR = fmla.GetWffSubformulae(wffD)
 
 		for _, j1 = range lns {
-			if j1i = j1.GetLineInfo(); !fmla.IsIdentical(j1i.Wff, wffDL) {
+			if j1i = j1.GetLineInfo(); !fmla.AlphaEquivalent(j1i.Wff, wffDL) {
 				continue
 			}
 			for _, j2 = range lns {
-				if j2i = j2.GetLineInfo(); !fmla.IsIdentical(j2i.Wff, wffDR) {
+				if j2i = j2.GetLineInfo(); !fmla.AlphaEquivalent(j2i.Wff, wffDR) {
 					continue
 				}
 
@@ -105,7 +105,7 @@ var tryVeeIntro ndRuleFunc = func(prf *pr.Proof) (added uint) {
 		wffDL, wffDR = fmla.GetWffSubformulae(wffD)
 
 		for _, j1 = range lns {
-			if j1i = j1.GetLineInfo(); fmla.IsIdentical(j1i.Wff, wffDL) || fmla.IsIdentical(j1i.Wff, wffDR) {
+			if j1i = j1.GetLineInfo(); fmla.AlphaEquivalent(j1i.Wff, wffDL) || fmla.AlphaEquivalent(j1i.Wff, wffDR) {
 				added += prf.AddUniqueLine(wffD, pr.VeeIntro, j1)
 			}
 		}
@@ -137,15 +137,15 @@ var tryIffIntro ndRuleFunc = func(prf *pr.Proof) (added uint) {
 
 		for _, j1 = range lns {
 			if j1i = j1.GetLineInfo(); !(j1i.Mop == fmla.To &&
-				fmla.IsIdentical(j1i.SubL, wffDL) &&
-				fmla.IsIdentical(j1i.SubR, wffDR)) {
+				fmla.AlphaEquivalent(j1i.SubL, wffDL) &&
+				fmla.AlphaEquivalent(j1i.SubR, wffDR)) {
 				continue
 			}
 
 			for _, j2 = range lns {
 				if j2i = j2.GetLineInfo(); !(j2i.Mop == fmla.To &&
-					fmla.IsIdentical(j2i.SubL, wffDR) &&
-					fmla.IsIdentical(j2i.SubR, wffDL)) {
+					fmla.AlphaEquivalent(j2i.SubL, wffDR) &&
+					fmla.AlphaEquivalent(j2i.SubR, wffDL)) {
 					continue
 				}
 
@@ -170,7 +170,7 @@ var tryReit ndRuleFunc = func(prf *pr.Proof) (added uint) {
 	contains = func(ln *pr.Line) (has bool) {
 		var li *pr.LineInfo = ln.GetLineInfo()
 
-		has = fmla.IsIdentical(li.Wff, j1i.Wff)
+		has = fmla.AlphaEquivalent(li.Wff, j1i.Wff)
 
 		return
 	}
@@ -204,7 +204,7 @@ var tryBotIntro ndRuleFunc = func(prf *pr.Proof) (added uint) {
 		j1i = j1.GetLineInfo()
 
 		for _, j2 = range lns {
-			if j2i = j2.GetLineInfo(); !(j2i.Mop == fmla.Neg && fmla.IsIdentical(j2i.SubL, j1i.Wff)) {
+			if j2i = j2.GetLineInfo(); !(j2i.Mop == fmla.Neg && fmla.AlphaEquivalent(j2i.SubL, j1i.Wff)) {
 				continue
 			}
 
@@ -298,6 +298,7 @@ var tryExistsIntro ndRuleFunc = func(prf *pr.Proof) (added uint) {
 		acs        []fmla.Argument
 		pv, pc     fmla.Predicate
 		av, ac     fmla.Argument
+		t          *fmla.Term
 	)
 
 	goals = prf.PopMetSubgoals()
@@ -306,6 +307,10 @@ var tryExistsIntro ndRuleFunc = func(prf *pr.Proof) (added uint) {
 
 TRYEXISTSINTRO_OUTER:
 	for _, wffD = range goals {
+		if fmla.GetWffKind(wffD) != fmla.Quantified {
+			continue
+		}
+
 		if mop, pv, av = fmla.GetWffMopAndVars(wffD); mop != fmla.Exists {
 			continue
 		}
@@ -318,7 +323,7 @@ TRYEXISTSINTRO_OUTER:
 				wffI = fmla.Instantiate(wffD, pc, 0)
 
 				for _, j1 = range lns {
-					if j1i = j1.GetLineInfo(); !fmla.IsIdentical(j1i.Wff, wffI) {
+					if j1i = j1.GetLineInfo(); !fmla.AlphaEquivalent(j1i.Wff, wffI) {
 						continue
 					}
 
@@ -332,7 +337,21 @@ TRYEXISTSINTRO_OUTER:
 				wffI = fmla.Instantiate(wffD, 0, ac)
 
 				for _, j1 = range lns {
-					if j1i = j1.GetLineInfo(); !fmla.IsIdentical(j1i.Wff, wffI) {
+					if j1i = j1.GetLineInfo(); !fmla.AlphaEquivalent(j1i.Wff, wffI) {
+						continue
+					}
+
+					added += prf.AddUniqueLine(wffD, pr.ExistsIntro, j1)
+
+					continue TRYEXISTSINTRO_OUTER
+				}
+			}
+
+			for _, t = range prf.SelectLineTerms() {
+				wffI = fmla.InstantiateTerm(wffD, t)
+
+				for _, j1 = range lns {
+					if j1i = j1.GetLineInfo(); !fmla.AlphaEquivalent(j1i.Wff, wffI) {
 						continue
 					}
 
@@ -351,6 +370,7 @@ var tryEqualsIntro ndRuleFunc = func(prf *pr.Proof) (added uint) {
 	var (
 		acs  []fmla.Argument
 		ac   fmla.Argument
+		t    *fmla.Term
 		wffD *fmla.WffTree
 	)
 
@@ -362,6 +382,12 @@ var tryEqualsIntro ndRuleFunc = func(prf *pr.Proof) (added uint) {
 		added += prf.AddUniqueLine(wffD, pr.EqualsIntro)
 	}
 
+	for _, t = range prf.SelectGoalTerms() {
+		wffD = fmla.NewAtomicTermWff(fmla.Equals, t, t)
+
+		added += prf.AddUniqueLine(wffD, pr.EqualsIntro)
+	}
+
 	return
 }
 
@@ -437,8 +463,7 @@ var tryIntroD ndRuleFunc = func(prf *pr.Proof) (added uint) {
 			continue
 		}
 
-		wffD, _ = fmla.GetWffSubformulae(j1i.SubL)
-		wffD = fmla.NewCompositeWff(fmla.Diamond, wffD, nil, 0, 0)
+		wffD = fmla.NewCompositeWff(fmla.Diamond, j1i.SubL, nil, 0, 0)
 
 		added += prf.AddUniqueLine(wffD, pr.IntroD, j1)
 	}
@@ -1,31 +1,24 @@
 package pr
 
-import "Deriver/fmla"
-
+import (
+	"Deriver/fmla"
+	"cmp"
+	"slices"
+)
+
+// Since indexed constants make the alphabets unbounded,
+// a constant missing from a domain's maps is simply absent from the proof.
 type domain struct {
 	pcs map[fmla.Predicate]bool // A map as to whether a predicate is present in the proof.
 	acs map[fmla.Argument]bool  // A map as to whether an argument is present in the proof.
 }
 
 func newDomain() (dom *domain) {
-	var (
-		pc fmla.Predicate
-		ac fmla.Argument
-	)
-
 	dom = &domain{
 		pcs: map[fmla.Predicate]bool{},
 		acs: map[fmla.Argument]bool{},
 	}
 
-	for _, pc = range fmla.PredConsts {
-		dom.pcs[pc] = false
-	}
-
-	for _, ac = range fmla.ArgConsts {
-		dom.acs[ac] = false
-	}
-
 	return
 }
 
@@ -90,3 +83,37 @@ func findArbConsts(dom *domain, wff *fmla.WffTree) (apc fmla.Predicate, aac fmla
 
 	return
 }
+
+func presentConsts(dom *domain) (pcs []fmla.Predicate, acs []fmla.Argument) {
+	var (
+		pc fmla.Predicate
+		ac fmla.Argument
+	)
+
+	for pc = range dom.pcs {
+		if dom.pcs[pc] {
+			pcs = append(pcs, pc)
+		}
+	}
+
+	for ac = range dom.acs {
+		if dom.acs[ac] {
+			acs = append(acs, ac)
+		}
+	}
+
+	// Map iteration is random, so order the constants as their alphabets do.
+	slices.SortFunc(pcs, func(pcA, pcB fmla.Predicate) (comp int) {
+		comp = cmp.Compare(fmla.PredOrdinal(pcA), fmla.PredOrdinal(pcB))
+
+		return
+	})
+
+	slices.SortFunc(acs, func(acA, acB fmla.Argument) (comp int) {
+		comp = cmp.Compare(fmla.ArgOrdinal(acA), fmla.ArgOrdinal(acB))
+
+		return
+	})
+
+	return
+}
@@ -60,7 +60,11 @@ func (ln *Line) GetLineInfo() (li *LineInfo) {
 		J3:   ln.j3,
 	}
 
-	li.Mop, li.PVar, li.AVar = fmla.GetWffMopAndVars(li.Wff)
+	li.Mop = fmla.GetWffMop(li.Wff)
+
+	if fmla.GetWffKind(li.Wff) == fmla.Quantified {
+		_, li.PVar, li.AVar = fmla.GetWffMopAndVars(li.Wff)
+	}
 
 	li.SubL, li.SubR = fmla.GetWffSubformulae(li.Wff)
 
@@ -124,18 +128,22 @@ func (prf *Proof) GetLegalLines() (lns []*Line) {
 func (prf *Proof) LineIsRedundant(ln *Line) (is bool) {
 	var (
 		lns []*Line
+		h   uint64
 	)
 
 	lns = prf.GetLegalLines()
 
+	h = fmla.AlphaHash(ln.wff)
+
 	is = slices.ContainsFunc(lns, func(l *Line) (has bool) {
-		has = fmla.IsIdentical(l.wff, ln.wff) &&
-			l.wld == ln.wld &&
+		has = l.wld == ln.wld &&
 			l.rule == ln.rule &&
 			// TODO: Check if pointer equality is stable.
 			l.j1 == ln.j1 &&
 			l.j2 == ln.j2 &&
-			l.j3 == ln.j3
+			l.j3 == ln.j3 &&
+			fmla.AlphaHash(l.wff) == h &&
+			fmla.AlphaEquivalent(l.wff, ln.wff)
 
 		return
 	})
@@ -144,13 +152,21 @@ func (prf *Proof) LineIsRedundant(ln *Line) (is bool) {
 }
 
 func (prf *Proof) InnerProofIsRedundant(prfI *Proof) (is bool) {
+	var (
+		hA, hG uint64
+	)
+
+	hA, hG = fmla.AlphaHash(prfI.lns[0].wff), fmla.AlphaHash(prfI.hGoal)
+
 	is = slices.ContainsFunc(prf.inner, func(p *Proof) (has bool) {
 		has = p.purp == prfI.purp &&
-			fmla.IsIdentical(p.lns[0].wff, prfI.lns[0].wff) &&
-			fmla.IsIdentical(p.hGoal, prfI.hGoal) &&
 			p.wld == prfI.wld &&
 			p.arbPC == prfI.arbPC &&
-			p.arbAC == prfI.arbAC
+			p.arbAC == prfI.arbAC &&
+			fmla.AlphaHash(p.lns[0].wff) == hA &&
+			fmla.AlphaHash(p.hGoal) == hG &&
+			fmla.AlphaEquivalent(p.lns[0].wff, prfI.lns[0].wff) &&
+			fmla.AlphaEquivalent(p.hGoal, prfI.hGoal)
 
 		return
 	})
@@ -164,6 +180,12 @@ func (prf *Proof) GetArbConstsFromProof() (apc fmla.Predicate, aac fmla.Argument
 	return
 }
 
+func (prf *Proof) GetDepth() (depth uint) {
+	depth = uint(len(prf.pid))
+
+	return
+}
+
 func (prf *Proof) GetInnerProofs(purp NDRule) (prfsI []*Proof) {
 	var (
 		prfI *Proof
@@ -232,6 +254,8 @@ func NewBaseProof(goal *fmla.WffTree, prems ...*fmla.WffTree) (prf *Proof) {
 
 		if !prf.LineIsRedundant(ln) {
 			prf.lns = append(prf.lns, ln)
+
+			prf.dom = updateDomain(prf.dom, ln.wff)
 		}
 	}
 
@@ -244,7 +268,7 @@ func (prf *Proof) HeadGoalMet() (ln0, lnX *Line, met bool) {
 	)
 
 	for _, ln = range prf.lns {
-		if met = fmla.IsIdentical(ln.wff, prf.hGoal); met {
+		if met = fmla.AlphaEquivalent(ln.wff, prf.hGoal); met {
 			ln0, lnX = prf.lns[0], ln
 
 			break
@@ -260,12 +284,12 @@ func (prf *Proof) MeetsAnyGoal(wff *fmla.WffTree) (met bool) {
 	)
 
 	contains = func(g *fmla.WffTree) (has bool) {
-		has = fmla.IsIdentical(g, wff)
+		has = fmla.AlphaEquivalent(g, wff)
 
 		return
 	}
 
-	met = fmla.IsIdentical(wff, prf.hGoal) || slices.ContainsFunc(prf.sGoals, contains)
+	met = fmla.AlphaEquivalent(wff, prf.hGoal) || slices.ContainsFunc(prf.sGoals, contains)
 
 	return
 }
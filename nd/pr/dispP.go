@@ -74,6 +74,7 @@ func flattenProofToFitchLines(prf *Proof, lnsM map[*Line]struct{}) (fls []*Fitch
 		fl = &FitchLine{
 			ln:    prf.lns[dex],
 			pid:   append([]uint{}, prf.pid...),
+			depth: uint(len(prf.pid)),
 			LnNum: 0, // This will be filled in later.
 			Fmla:  fmla.GetWffString(ln.wff),
 			Just:  ndRuleToName[ln.rule],
@@ -230,45 +231,60 @@ func reinsertBoundFitchLines(flsF []*FitchLine, flsB []*FitchLine) (fls []*Fitch
 	return
 }
 
-func formatJustField(flN *FitchLine, fls []*FitchLine) (js string) {
+// findJustLineNums finds the line number of each justification, or 0 for one not among the lines.
+func findJustLineNums(flN *FitchLine, fls []*FitchLine) (jlns []uint) {
 	var (
-		jln1, jln2, jln3 uint
-		fl               *FitchLine
-		findJLn          func(ln *Line, fls []*FitchLine) (jln uint)
+		jln  *Line
+		fl   *FitchLine
+		lnum uint
 	)
 
-	js = ndRuleToName[flN.ln.rule]
+	for _, jln = range []*Line{flN.ln.j1, flN.ln.j2, flN.ln.j3} {
+		// A nil justification means that the later ones are nil, too.
+		if jln == nil {
+			break
+		}
+
+		lnum = 0
 
-	findJLn = func(ln *Line, fls []*FitchLine) (jln uint) {
 		for _, fl = range fls {
-			if fl.ln == ln {
-				jln = fl.LnNum
+			if fl.ln == jln {
+				lnum = fl.LnNum
 
 				break
 			}
 		}
 
-		return
+		jlns = append(jlns, lnum)
+	}
+
+	return
+}
+
+func joinLineNums(jlns []uint) (s string) {
+	var (
+		ss  []string
+		jln uint
+	)
+
+	for _, jln = range jlns {
+		ss = append(ss, fmt.Sprintf("%d", jln))
 	}
 
-	switch {
-	case flN.ln.j1 == nil:
-		// Do nothing, since this means j2 and j3 are nil.
-	case flN.ln.j2 == nil:
-		jln1 = findJLn(flN.ln.j1, fls)
+	s = strings.Join(ss, ", ")
 
-		js += fmt.Sprintf(" (%d)", jln1)
-	case flN.ln.j3 == nil:
-		jln1 = findJLn(flN.ln.j1, fls)
-		jln2 = findJLn(flN.ln.j2, fls)
+	return
+}
 
-		js += fmt.Sprintf(" (%d, %d)", jln1, jln2)
-	default:
-		jln1 = findJLn(flN.ln.j1, fls)
-		jln2 = findJLn(flN.ln.j2, fls)
-		jln3 = findJLn(flN.ln.j3, fls)
+func formatJustField(flN *FitchLine, fls []*FitchLine) (js string) {
+	var (
+		jlns []uint
+	)
+
+	js = ndRuleToName[flN.ln.rule]
 
-		js += fmt.Sprintf(" (%d, %d, %d)", jln1, jln2, jln3)
+	if jlns = findJustLineNums(flN, fls); 0 < len(jlns) {
+		js += " (" + joinLineNums(jlns) + ")"
 	}
 
 	return
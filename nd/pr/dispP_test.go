@@ -0,0 +1,88 @@
+package pr
+
+import (
+	"Deriver/fmla"
+	"slices"
+	"testing"
+)
+
+// newSwapProof builds C ⊢ C∧((A∧B)→(B∧A)) by hand, with one subproof
+// for the conditional, so that the renderers have a fixed proof to print.
+func newSwapProof() (prf *Proof) {
+	var (
+		mustParse          func(s string) (wff *fmla.WffTree)
+		prfI               *Proof
+		lnC, lnH, lnB, lnA *Line
+		lnBA, lnTo         *Line
+	)
+
+	mustParse = func(s string) (wff *fmla.WffTree) {
+		var err error
+
+		if wff, err = fmla.ParseWff(s); err != nil {
+			panic(err)
+		}
+
+		return
+	}
+
+	prf = NewBaseProof(mustParse("C∧((A∧B)→(B∧A))"), mustParse("C"))
+
+	lnC = prf.lns[1]
+
+	prf.AddUniqueInnerProof(mustParse("A∧B"), mustParse("B∧A"), ToIntro)
+
+	prfI = prf.inner[0]
+
+	lnH = prfI.lns[0]
+
+	prfI.AddUniqueLine(mustParse("B"), WedgeElim, lnH)
+	prfI.AddUniqueLine(mustParse("A"), WedgeElim, lnH)
+
+	lnB, lnA = prfI.lns[1], prfI.lns[2]
+
+	prfI.AddUniqueLine(mustParse("B∧A"), WedgeIntro, lnB, lnA)
+
+	lnBA = prfI.lns[3]
+
+	prf.AddUniqueLine(mustParse("(A∧B)→(B∧A)"), ToIntro, lnH, lnBA)
+
+	lnTo = prf.lns[2]
+
+	prf.AddUniqueLine(mustParse("C∧((A∧B)→(B∧A))"), WedgeIntro, lnC, lnTo)
+
+	return
+}
+
+func TestNewFitchLines(t *testing.T) {
+	var (
+		prf      *Proof
+		fls      []*FitchLine
+		s        string
+		met      bool
+		expected string
+	)
+
+	prf = newSwapProof()
+
+	expected = "   1.| A∧B                 by SM→I\n" +
+		"   2.| A               by ∧E (1)→I\n" +
+		"   3.| B               by ∧E (1)→I\n" +
+		"   4.| B∧A          by ∧I (3, 2)→I\n" +
+		"   5. (A∧B)→(B∧A)     by →I (1, 4)\n" +
+		"   6. C                      by PR\n" +
+		"   7. C∧((A∧B)→(B∧A)) by ∧I (6, 5)"
+
+	if s, met = NewFitchLineString(prf); !met || s != expected {
+		t.Errorf("\nFAILED: Expected the Fitch proof\n%s\ngot\n%s", expected, s)
+	} else {
+		t.Logf("\nPASSED:\n%s", s)
+	}
+
+	// A justification missing from the lines still holds its place.
+	fls, _ = NewFitchLines(prf)
+
+	if s = formatJustField(fls[len(fls)-1], slices.Delete(slices.Clone(fls), 5, 6)); s != "∧I (0, 5)" {
+		t.Errorf("\nFAILED: Expected %q with a placeholder for the missing line, got %q.", "∧I (0, 5)", s)
+	}
+}
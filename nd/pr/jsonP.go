@@ -0,0 +1,363 @@
+package pr
+
+import (
+	"Deriver/fmla"
+	"encoding/json"
+	"fmt"
+)
+
+var ndRuleToKey map[NDRule]string = map[NDRule]string{
+	Solve:        "Solve",
+	Premise:      "Premise",
+	Theorem:      "Theorem",
+	Assumption:   "Assumption",
+	TopIntro:     "TopIntro",
+	ToIntro:      "ToIntro",
+	ToElim:       "ToElim",
+	WedgeIntro:   "WedgeIntro",
+	WedgeElim:    "WedgeElim",
+	VeeIntro:     "VeeIntro",
+	VeeElim:      "VeeElim",
+	IffIntro:     "IffIntro",
+	IffElim:      "IffElim",
+	Reit:         "Reit",
+	BotIntro:     "BotIntro",
+	NegIntro:     "NegIntro",
+	BotElim:      "BotElim",
+	NegElim:      "NegElim",
+	ForAllIntro:  "ForAllIntro",
+	ForAllElim:   "ForAllElim",
+	ExistsIntro:  "ExistsIntro",
+	ExistsElim:   "ExistsElim",
+	EqualsIntro:  "EqualsIntro",
+	EqualsElim:   "EqualsElim",
+	BoxIntro:     "BoxIntro",
+	BoxElim:      "BoxElim",
+	DiamondElim:  "DiamondElim",
+	DiamondIntro: "DiamondIntro",
+	IntroD:       "IntroD",
+	IntroM:       "IntroM",
+	ElimM:        "ElimM",
+	Intro4:       "Intro4",
+	Elim4:        "Elim4",
+	IntroB:       "IntroB",
+	ElimB:        "ElimB",
+}
+
+// lineJSON is the JSON schema of a Line. Justifications refer to the ids of other lines.
+type lineJSON struct {
+	ID   uint          `json:"id"`
+	Wff  *fmla.WffTree `json:"wff"`
+	Wld  world         `json:"world"`
+	Rule NDRule        `json:"rule"`
+	Just []uint        `json:"just,omitempty"`
+}
+
+// proofJSON is the JSON schema of a Proof. Line ids number the lines of the proof
+// and its inner proofs in preorder, starting from 0.
+type proofJSON struct {
+	PID    []uint          `json:"pid"`
+	Purp   NDRule          `json:"purpose"`
+	HGoal  *fmla.WffTree   `json:"goal"`
+	SGoals []*fmla.WffTree `json:"subgoals"`
+	Lns    []*lineJSON     `json:"lines"`
+	Wld    world           `json:"world"`
+	ArbPC  fmla.Predicate  `json:"arbPred,omitempty"`
+	ArbAC  fmla.Argument   `json:"arbArg,omitempty"`
+	Inner  []*proofJSON    `json:"inner"`
+}
+
+func (rule NDRule) MarshalText() (bs []byte, err error) {
+	var (
+		key string
+		ok  bool
+	)
+
+	if key, ok = ndRuleToKey[rule]; !ok {
+		err = fmt.Errorf("pr: invalid rule %d", rule)
+
+		return
+	}
+
+	bs = []byte(key)
+
+	return
+}
+
+func (rule *NDRule) UnmarshalText(bs []byte) (err error) {
+	var (
+		r   NDRule
+		key string
+	)
+
+	for r, key = range ndRuleToKey {
+		if key == string(bs) {
+			*rule = r
+
+			return
+		}
+	}
+
+	err = fmt.Errorf("pr: invalid rule %q", bs)
+
+	return
+}
+
+func numberLines(prf *Proof, ids map[*Line]uint) {
+	var (
+		ln   *Line
+		prfI *Proof
+	)
+
+	for _, ln = range prf.lns {
+		ids[ln] = uint(len(ids))
+	}
+
+	for _, prfI = range prf.inner {
+		numberLines(prfI, ids)
+	}
+}
+
+func newProofJSON(prf *Proof, ids map[*Line]uint) (pj *proofJSON, err error) {
+	var (
+		ln   *Line
+		lj   *lineJSON
+		jln  *Line
+		id   uint
+		ok   bool
+		prfI *Proof
+		pjI  *proofJSON
+	)
+
+	pj = &proofJSON{
+		PID:    append([]uint{}, prf.pid...),
+		Purp:   prf.purp,
+		HGoal:  prf.hGoal,
+		SGoals: append([]*fmla.WffTree{}, prf.sGoals...),
+		Lns:    []*lineJSON{},
+		Wld:    prf.wld,
+		ArbPC:  prf.arbPC,
+		ArbAC:  prf.arbAC,
+		Inner:  []*proofJSON{},
+	}
+
+	for _, ln = range prf.lns {
+		lj = &lineJSON{ID: ids[ln], Wff: ln.wff, Wld: ln.wld, Rule: ln.rule}
+
+		for _, jln = range []*Line{ln.j1, ln.j2, ln.j3} {
+			if jln == nil {
+				break
+			}
+
+			if id, ok = ids[jln]; !ok {
+				err = fmt.Errorf("pr: line %d is justified outside the proof", lj.ID)
+
+				return
+			}
+
+			lj.Just = append(lj.Just, id)
+		}
+
+		pj.Lns = append(pj.Lns, lj)
+	}
+
+	for _, prfI = range prf.inner {
+		if pjI, err = newProofJSON(prfI, ids); err != nil {
+			return
+		}
+
+		pj.Inner = append(pj.Inner, pjI)
+	}
+
+	return
+}
+
+// MarshalJSON writes the proof with its inner proofs, which must hold
+// every line that justifies another.
+func (prf *Proof) MarshalJSON() (bs []byte, err error) {
+	var (
+		ids map[*Line]uint
+		pj  *proofJSON
+	)
+
+	ids = map[*Line]uint{}
+
+	numberLines(prf, ids)
+
+	if pj, err = newProofJSON(prf, ids); err != nil {
+		return
+	}
+
+	bs, err = json.Marshal(pj)
+
+	return
+}
+
+// buildProof rebuilds the proofs and lines, leaving justifications to linkLines.
+func buildProof(pj *proofJSON, outer *Proof, lnsI map[uint]*Line) (prf *Proof, err error) {
+	var (
+		dex  int
+		lj   *lineJSON
+		ln   *Line
+		ok   bool
+		pjI  *proofJSON
+		prfI *Proof
+	)
+
+	if pj.HGoal == nil || len(pj.Lns) == 0 {
+		err = fmt.Errorf("pr: proof %v needs a goal and at least one line", pj.PID)
+
+		return
+	}
+
+	prf = &Proof{
+		pid: append([]uint{}, pj.PID...),
+
+		purp:   pj.Purp,
+		hGoal:  pj.HGoal,
+		sGoals: append([]*fmla.WffTree{}, pj.SGoals...),
+
+		lns:   []*Line{},
+		wld:   pj.Wld,
+		arbPC: pj.ArbPC,
+		arbAC: pj.ArbAC,
+		dom:   newDomain(),
+
+		inner: []*Proof{},
+		outer: outer,
+	}
+
+	if outer != nil {
+		prf.dom = outer.dom
+	}
+
+	prf.dom = updateDomain(prf.dom, prf.hGoal)
+
+	for dex, lj = range pj.Lns {
+		if lj.Wff == nil {
+			err = fmt.Errorf("pr: line %d has no formula", lj.ID)
+
+			return
+		}
+
+		if _, ok = lnsI[lj.ID]; ok {
+			err = fmt.Errorf("pr: line id %d is not unique", lj.ID)
+
+			return
+		}
+
+		ln = &Line{
+			dex: uint(dex),
+
+			wff: lj.Wff,
+			wld: lj.Wld,
+
+			rule: lj.Rule,
+		}
+
+		prf.lns = append(prf.lns, ln)
+
+		prf.dom = updateDomain(prf.dom, ln.wff)
+
+		lnsI[lj.ID] = ln
+	}
+
+	for _, pjI = range pj.Inner {
+		if prfI, err = buildProof(pjI, prf, lnsI); err != nil {
+			return
+		}
+
+		prf.inner = append(prf.inner, prfI)
+	}
+
+	return
+}
+
+func linkLines(pj *proofJSON, lnsI map[uint]*Line) (err error) {
+	var (
+		lj  *lineJSON
+		ln  *Line
+		jls []*Line
+		dex int
+		id  uint
+		jln *Line
+		ok  bool
+		pjI *proofJSON
+	)
+
+	for _, lj = range pj.Lns {
+		ln = lnsI[lj.ID]
+
+		if ln.rule == Assumption {
+			ok = correctJCount(Assumption, pj.Purp, len(lj.Just))
+		} else {
+			ok = correctJCount(ln.rule, 0, len(lj.Just))
+		}
+
+		if !ok {
+			err = fmt.Errorf("pr: line %d has %d justifications, which its rule does not take", lj.ID, len(lj.Just))
+
+			return
+		}
+
+		jls = []*Line{nil, nil, nil}
+
+		for dex, id = range lj.Just {
+			if jln, ok = lnsI[id]; !ok {
+				err = fmt.Errorf("pr: line %d cites the missing line %d", lj.ID, id)
+
+				return
+			}
+
+			jls[dex] = jln
+		}
+
+		ln.j1, ln.j2, ln.j3 = jls[0], jls[1], jls[2]
+	}
+
+	for _, pjI = range pj.Inner {
+		if err = linkLines(pjI, lnsI); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+// UnmarshalJSON rebuilds the proof, relinking its lines' justifications, its inner proofs'
+// outer proofs, and the domains from which arbitrary constants are chosen.
+// Like encoding/json itself, it leaves the proof as it was for null.
+func (prf *Proof) UnmarshalJSON(bs []byte) (err error) {
+	var (
+		pj   proofJSON
+		lnsI map[uint]*Line
+		prfB *Proof
+		prfI *Proof
+	)
+
+	if string(bs) == "null" {
+		return
+	}
+
+	if err = json.Unmarshal(bs, &pj); err != nil {
+		return
+	}
+
+	lnsI = map[uint]*Line{}
+
+	if prfB, err = buildProof(&pj, nil, lnsI); err != nil {
+		return
+	}
+
+	if err = linkLines(&pj, lnsI); err != nil {
+		return
+	}
+
+	*prf = *prfB
+
+	for _, prfI = range prf.inner {
+		prfI.outer = prf
+	}
+
+	return
+}
@@ -0,0 +1,127 @@
+package pr
+
+import (
+	"Deriver/fmla"
+	"encoding/json"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestProofJSON(t *testing.T) {
+	var (
+		prf, prfR     *Proof
+		wffT, wffC    *fmla.WffTree
+		ids, idsR     map[*Line]uint
+		bs, bsR       []byte
+		err           error
+		compareProofs func(prfA, prfB *Proof)
+		justIDs       func(ln *Line, ids map[*Line]uint) (jids []uint)
+		equalWffs     func(wffsA, wffsB []*fmla.WffTree) (eq bool)
+	)
+
+	justIDs = func(ln *Line, ids map[*Line]uint) (jids []uint) {
+		var jln *Line
+
+		for _, jln = range []*Line{ln.j1, ln.j2, ln.j3} {
+			if jln != nil {
+				jids = append(jids, ids[jln])
+			}
+		}
+
+		return
+	}
+
+	equalWffs = func(wffsA, wffsB []*fmla.WffTree) (eq bool) {
+		eq = slices.EqualFunc(wffsA, wffsB, fmla.IsIdentical)
+
+		return
+	}
+
+	compareProofs = func(prfA, prfB *Proof) {
+		var (
+			dex      int
+			lnA, lnB *Line
+			prfIA    *Proof
+		)
+
+		if !slices.Equal(prfA.pid, prfB.pid) || prfA.purp != prfB.purp || prfA.wld != prfB.wld ||
+			!fmla.IsIdentical(prfA.hGoal, prfB.hGoal) || !equalWffs(prfA.sGoals, prfB.sGoals) {
+			t.Errorf("\nFAILED: Expected proof %v (%v, world %d, goals %q) to survive, got %v (%v, world %d, goals %q).",
+				prfA.pid, prfA.purp, prfA.wld, fmla.GetWffString(prfA.hGoal),
+				prfB.pid, prfB.purp, prfB.wld, fmla.GetWffString(prfB.hGoal))
+		}
+
+		if len(prfA.lns) != len(prfB.lns) || len(prfA.inner) != len(prfB.inner) {
+			t.Fatalf("\nFAILED: Expected proof %v to keep %d lines and %d inner proofs, got %d and %d.",
+				prfA.pid, len(prfA.lns), len(prfA.inner), len(prfB.lns), len(prfB.inner))
+		}
+
+		for dex, lnA = range prfA.lns {
+			lnB = prfB.lns[dex]
+
+			if ids[lnA] != idsR[lnB] || lnA.dex != lnB.dex || lnA.wld != lnB.wld || lnA.rule != lnB.rule ||
+				!fmla.IsIdentical(lnA.wff, lnB.wff) || !slices.Equal(justIDs(lnA, ids), justIDs(lnB, idsR)) {
+				t.Errorf("\nFAILED: Expected line %d, %s in world %d by %v %v, got line %d, %s in world %d by %v %v.",
+					ids[lnA], fmla.GetWffString(lnA.wff), lnA.wld, lnA.rule, justIDs(lnA, ids),
+					idsR[lnB], fmla.GetWffString(lnB.wff), lnB.wld, lnB.rule, justIDs(lnB, idsR))
+			}
+		}
+
+		for dex, prfIA = range prfA.inner {
+			if prfB.inner[dex].outer != prfB {
+				t.Errorf("\nFAILED: Expected inner proof %v to link back to its outer proof.", prfB.inner[dex].pid)
+			}
+
+			compareProofs(prfIA, prfB.inner[dex])
+		}
+	}
+
+	prf = newSwapProof()
+
+	// A □I subproof puts lines in world 1, and a subgoal rides along in the base proof.
+	wffT, _ = fmla.ParseWff("⊤")
+	wffC, _ = fmla.ParseWff("C")
+
+	prf.AddUniqueInnerProof(wffT, wffC, BoxIntro)
+
+	prf.inner[1].AddUniqueLine(wffT, TopIntro)
+
+	prf.ExtendSubgoals(wffC)
+
+	if bs, err = json.Marshal(prf); err != nil {
+		t.Fatalf("\nFAILED: %v", err)
+	}
+
+	prfR = &Proof{}
+
+	if err = json.Unmarshal(bs, prfR); err != nil {
+		t.Fatalf("\nFAILED: %v", err)
+	}
+
+	ids, idsR = map[*Line]uint{}, map[*Line]uint{}
+
+	numberLines(prf, ids)
+
+	numberLines(prfR, idsR)
+
+	compareProofs(prf, prfR)
+
+	if prfR.inner[1].wld != 1 || prfR.inner[1].lns[1].wld != 1 {
+		t.Errorf("\nFAILED: Expected the □I subproof and its lines in world 1.")
+	}
+
+	if bsR, err = json.Marshal(prfR); err != nil || string(bsR) != string(bs) {
+		t.Errorf("\nFAILED: Expected the same JSON again\n%s\ngot\n%s", bs, bsR)
+	} else {
+		t.Logf("\nPASSED: %s", bs)
+	}
+
+	if err = json.Unmarshal([]byte("null"), prfR); err != nil || len(prfR.inner) != 2 {
+		t.Errorf("\nFAILED: Expected null to leave the proof alone, got %v.", err)
+	}
+
+	if err = json.Unmarshal([]byte(`{"pid":[],"purpose":"Solve","goal":{"kind":"atomic","pred":"P"},"lines":[{"id":0,"wff":{"kind":"atomic","pred":"P"},"rule":"ToElim","just":[0]}]}`), prfR); err == nil || !strings.Contains(err.Error(), "justifications") {
+		t.Errorf("\nFAILED: Expected an error for the wrong number of justifications, got %v.", err)
+	}
+}
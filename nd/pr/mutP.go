@@ -117,7 +117,7 @@ func (prf *Proof) AddUniqueInnerProof(wff, goal *fmla.WffTree, purp NDRule, js .
 	}
 
 	prfI = &Proof{
-		pid: append(prf.pid, uint(len(prf.inner))),
+		pid: append(slices.Clone(prf.pid), uint(len(prf.inner))),
 
 		purp:   purp,
 		hGoal:  fmla.DeepCopy(goal),
@@ -149,13 +149,13 @@ func (prf *Proof) ExtendSubgoals(goals ...*fmla.WffTree) (ok bool) {
 	)
 
 	contains = func(g *fmla.WffTree) (has bool) {
-		has = fmla.IsIdentical(g, goal)
+		has = fmla.AlphaEquivalent(g, goal)
 
 		return
 	}
 
 	for _, goal = range goals {
-		if goal == nil || fmla.IsIdentical(prf.hGoal, goal) || slices.ContainsFunc(prf.sGoals, contains) {
+		if goal == nil || fmla.AlphaEquivalent(prf.hGoal, goal) || slices.ContainsFunc(prf.sGoals, contains) {
 			continue
 		}
 
@@ -180,7 +180,7 @@ func (prf *Proof) PopMetSubgoals() (goals []*fmla.WffTree) {
 		delete = func(g *fmla.WffTree) (nix bool) {
 			var li *LineInfo = ln.GetLineInfo()
 
-			nix = fmla.IsIdentical(g, li.Wff)
+			nix = fmla.AlphaEquivalent(g, li.Wff)
 
 			return
 		}
@@ -197,53 +197,70 @@ func (prf *Proof) PopMetSubgoals() (goals []*fmla.WffTree) {
 
 func (prf *Proof) MustSelectArbConsts() (apc fmla.Predicate, aac fmla.Argument) {
 	var (
-		pc fmla.Predicate
-		ac fmla.Argument
+		dex uint
 	)
 
-	for _, pc = range fmla.PredConsts {
-		if !prf.dom.pcs[pc] {
-			apc = pc
-
-			break
-		}
+	// Indexed constants never run out, so the first ones absent from the domain are always found.
+	for dex = 0; prf.dom.pcs[fmla.NthPredConst(dex)]; dex += 1 {
 	}
 
-	for _, ac = range fmla.ArgConsts {
-		if !prf.dom.acs[ac] {
-			aac = ac
+	apc = fmla.NthPredConst(dex)
 
-			break
-		}
+	for dex = 0; prf.dom.acs[fmla.NthArgConst(dex)]; dex += 1 {
 	}
 
-	switch {
-	case apc == 0 && aac == 0:
-		panic("No arbitrary constants available.")
-	case apc != 0 && aac != 0:
-		panic("Both arbitrary constant kinds found.")
-	}
+	aac = fmla.NthArgConst(dex)
 
 	return
 }
 
 func (prf *Proof) SelectNonArbConsts() (pcs []fmla.Predicate, acs []fmla.Argument) {
+	pcs, acs = presentConsts(prf.dom)
+
+	return
+}
+
+func collectGroundTerms(wffs []*fmla.WffTree) (ts []*fmla.Term) {
 	var (
-		pc fmla.Predicate
-		ac fmla.Argument
+		wff *fmla.WffTree
+		t   *fmla.Term
 	)
 
-	for _, pc = range fmla.PredConsts {
-		if prf.dom.pcs[pc] {
-			pcs = append(pcs, pc)
+	for _, wff = range wffs {
+		for _, t = range fmla.GetGroundTerms(wff) {
+			if !slices.ContainsFunc(ts, func(tS *fmla.Term) (has bool) {
+				has = fmla.IsIdenticalTerm(tS, t)
+
+				return
+			}) {
+				ts = append(ts, t)
+			}
 		}
 	}
 
-	for _, ac = range fmla.ArgConsts {
-		if prf.dom.acs[ac] {
-			acs = append(acs, ac)
-		}
+	return
+}
+
+// SelectGoalTerms returns the ground function terms in the goals of the proof.
+// Unlike the terms on its lines, these cannot grow by instantiation.
+func (prf *Proof) SelectGoalTerms() (ts []*fmla.Term) {
+	ts = collectGroundTerms(prf.GetAllGoals())
+
+	return
+}
+
+// SelectLineTerms returns the ground function terms on the legal lines of the proof.
+func (prf *Proof) SelectLineTerms() (ts []*fmla.Term) {
+	var (
+		wffs []*fmla.WffTree
+		ln   *Line
+	)
+
+	for _, ln = range prf.GetLegalLines() {
+		wffs = append(wffs, ln.wff)
 	}
 
+	ts = collectGroundTerms(wffs)
+
 	return
 }
@@ -0,0 +1,85 @@
+package pr
+
+import (
+	"Deriver/fmla"
+	"slices"
+	"testing"
+)
+
+func TestAddUniqueInnerProofPIDs(t *testing.T) {
+	var (
+		prf, prfI  *Proof
+		s          string
+		wff        *fmla.WffTree
+		pidA, pidB []uint
+	)
+
+	wff, _ = fmla.ParseWff("P")
+
+	prf = NewBaseProof(wff)
+
+	// Three levels deep, the proof ID has room to spare, so appending to it in place
+	// would have the next two siblings share, and overwrite, one backing array.
+	prfI = prf
+
+	for _, s = range []string{"A", "B", "C"} {
+		wff, _ = fmla.ParseWff(s)
+
+		prfI.AddUniqueInnerProof(wff, wff, ToIntro)
+
+		prfI = prfI.inner[len(prfI.inner)-1]
+	}
+
+	for _, s = range []string{"D", "E"} {
+		wff, _ = fmla.ParseWff(s)
+
+		prfI.AddUniqueInnerProof(wff, wff, ToIntro)
+	}
+
+	pidA, pidB = prfI.inner[0].pid, prfI.inner[1].pid
+
+	if !slices.Equal(pidA, []uint{0, 0, 0, 0}) || !slices.Equal(pidB, []uint{0, 0, 0, 1}) {
+		t.Errorf("\nFAILED: Expected the proof IDs [0 0 0 0] and [0 0 0 1], got %v and %v.", pidA, pidB)
+	} else {
+		t.Logf("\nPASSED: The proof IDs %v and %v are distinct.", pidA, pidB)
+	}
+}
+
+func TestAlphaVariantGoalIsMet(t *testing.T) {
+	var (
+		prf              *Proof
+		goal, prem, wffX *fmla.WffTree
+		wffY             *fmla.WffTree
+		ln, lnE          *Line
+		met              bool
+	)
+
+	goal, _ = fmla.ParseWff("∃xFx∧∃yFy")
+	prem, _ = fmla.ParseWff("Fa")
+	wffX, _ = fmla.ParseWff("∃xFx")
+	wffY, _ = fmla.ParseWff("∃yFy")
+
+	prf = NewBaseProof(goal, prem)
+
+	prf.ExtendSubgoals(wffX, wffY)
+
+	ln = prf.lns[len(prf.lns)-1]
+
+	prf.AddUniqueLine(wffX, ExistsIntro, ln)
+
+	lnE = prf.lns[len(prf.lns)-1]
+
+	// ∃yFy is only an alpha-variant of the line just added, but it must still count as met,
+	// or no line could ever justify the right conjunct.
+	if !prf.MeetsAnyGoal(wffY) {
+		t.Errorf("\nFAILED: Expected ∃yFy to be met by the line ∃xFx.")
+	}
+
+	prf.AddUniqueLine(goal, WedgeIntro, lnE, lnE)
+
+	if _, _, met = prf.HeadGoalMet(); !met {
+		t.Errorf("\nFAILED: Expected ∃xFx∧∃yFy to be met.")
+	} else {
+		t.Logf("\nPASSED: The alpha-variant goal ∃yFy is met by ∃xFx.")
+	}
+}
@@ -88,7 +88,7 @@ var rulePCount map[NDRule]int = map[NDRule]int{
 	Reit:         1,
 	BotIntro:     2,
 	BotElim:      1,
-	NegIntro:     1,
+	NegIntro:     2,
 	NegElim:      1,
 	ForAllIntro:  2,
 	ForAllElim:   1,
@@ -0,0 +1,224 @@
+package pr
+
+import (
+	"Deriver/fmla"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+type LaTeXStyle uint
+
+const (
+	FitchSty LaTeXStyle = iota + 1 // Johan Klüwer's fitch.sty, with \hypo, \have, \open and \close.
+	LplFitch                       // lplfitch.sty, with \fitchprf, \subproof and \pline.
+)
+
+// fitchBlock is either a single Fitch line or a subproof of further blocks.
+type fitchBlock struct {
+	fl    *FitchLine    // The line, if the block is a line.
+	pid   []uint        // The proof ID of the subproof, if the block is a subproof.
+	inner []*fitchBlock // The blocks of the subproof, if the block is a subproof.
+}
+
+// nestFitchLines regroups Fitch lines, in the order given, into nested subproofs by their proof IDs.
+// A line whose proof ID isn't within the open subproof closes subproofs until it is.
+func nestFitchLines(fls []*FitchLine) (root *fitchBlock) {
+	var (
+		fl        *FitchLine
+		stack     []*fitchBlock
+		top, blkN *fitchBlock
+	)
+
+	root = &fitchBlock{pid: []uint{}}
+
+	stack = []*fitchBlock{root}
+
+	for _, fl = range fls {
+		top = stack[len(stack)-1]
+
+		for len(fl.pid) < len(top.pid) || !slices.Equal(top.pid, fl.pid[:len(top.pid)]) {
+			stack = stack[:len(stack)-1]
+
+			top = stack[len(stack)-1]
+		}
+
+		for len(top.pid) < len(fl.pid) {
+			blkN = &fitchBlock{pid: fl.pid[:len(top.pid)+1]}
+
+			top.inner = append(top.inner, blkN)
+
+			stack = append(stack, blkN)
+
+			top = blkN
+		}
+
+		top.inner = append(top.inner, &fitchBlock{fl: fl})
+	}
+
+	return
+}
+
+func isHypothesis(blk *fitchBlock) (is bool) {
+	is = blk.fl != nil && (blk.fl.ln.rule == Premise || blk.fl.ln.rule == Assumption)
+
+	return
+}
+
+// countHypotheses counts the hypotheses that open a block. A premise that NewFitchLines places
+// after a subproof isn't among them, and is justified as PR where it stands.
+func countHypotheses(blk *fitchBlock) (n int) {
+	for n < len(blk.inner) && isHypothesis(blk.inner[n]) {
+		n += 1
+	}
+
+	return
+}
+
+// ruleToLaTeX renders a rule's name for text mode, switching into math mode for logical symbols.
+func ruleToLaTeX(rule NDRule) (s string) {
+	var (
+		sb strings.Builder
+		r  rune
+		sL string
+	)
+
+	for _, r = range ndRuleToName[rule] {
+		if sL = fmla.StringToLaTeX(string(r)); sL != string(r) {
+			sb.WriteString("$" + sL + "$")
+		} else {
+			sb.WriteRune(r)
+		}
+	}
+
+	s = `\textrm{` + sb.String() + `}`
+
+	return
+}
+
+func writeFitchSty(sb *strings.Builder, blk *fitchBlock, fls []*FitchLine, depth int) {
+	var (
+		dex, lenH int
+		blkI      *fitchBlock
+		indent    string
+		wffL      string
+	)
+
+	indent = strings.Repeat("  ", depth+1)
+
+	lenH = countHypotheses(blk)
+
+	for dex, blkI = range blk.inner {
+		if blkI.fl == nil {
+			sb.WriteString(indent + `\open` + "\n")
+
+			writeFitchSty(sb, blkI, fls, depth+1)
+
+			sb.WriteString(indent + `\close` + "\n")
+
+			continue
+		}
+
+		wffL = fmla.GetWffLaTeX(blkI.fl.ln.wff, nil)
+
+		if dex < lenH {
+			fmt.Fprintf(sb, "%s\\hypo {%d} {%s}\n", indent, blkI.fl.LnNum, wffL)
+		} else {
+			fmt.Fprintf(sb, "%s\\have {%d} {%s} \\by{%s}{%s}\n", indent, blkI.fl.LnNum, wffL,
+				ruleToLaTeX(blkI.fl.ln.rule), joinLineNums(findJustLineNums(blkI.fl, fls)))
+		}
+	}
+}
+
+func newLplLine(blk *fitchBlock, fls []*FitchLine, hyp bool) (s string) {
+	var (
+		jlns []uint
+	)
+
+	s = fmt.Sprintf("\\pline[%d.]{%s}", blk.fl.LnNum, fmla.GetWffLaTeX(blk.fl.ln.wff, nil))
+
+	if !hyp {
+		s += "[" + ruleToLaTeX(blk.fl.ln.rule)
+
+		if jlns = findJustLineNums(blk.fl, fls); 0 < len(jlns) {
+			s += " " + joinLineNums(jlns)
+		}
+
+		s += "]"
+	}
+
+	return
+}
+
+// newLplBlock renders a block as its leading hypotheses and its body, the two arguments
+// of both \fitchprf and \subproof.
+func newLplBlock(blk *fitchBlock, fls []*FitchLine, depth int) (hyps, body string) {
+	var (
+		dex     int
+		blkI    *fitchBlock
+		ssH, ss []string
+		indent  string
+		h, b    string
+	)
+
+	indent = "\n" + strings.Repeat("  ", depth+1)
+
+	for dex = 0; dex < countHypotheses(blk); dex += 1 {
+		ssH = append(ssH, newLplLine(blk.inner[dex], fls, true))
+	}
+
+	for _, blkI = range blk.inner[dex:] {
+		if blkI.fl != nil {
+			ss = append(ss, newLplLine(blkI, fls, false))
+
+			continue
+		}
+
+		h, b = newLplBlock(blkI, fls, depth+1)
+
+		ss = append(ss, `\subproof{`+h+`}{`+b+`}`)
+	}
+
+	hyps = strings.Join(ssH, ` \\ `)
+
+	body = strings.Join(ss, ` \\`+indent)
+
+	return
+}
+
+// NewFitchLaTeX renders the proof of the head goal in LaTeX, with the numbering
+// and justifications of NewFitchLines, for the fitch.sty or lplfitch package.
+// Formulae use amssymb's \Box and \Diamond.
+func NewFitchLaTeX(prf *Proof, style LaTeXStyle) (s string, met bool) {
+	var (
+		fls        []*FitchLine
+		root       *fitchBlock
+		sb         strings.Builder
+		hyps, body string
+	)
+
+	if fls, met = NewFitchLines(prf); !met {
+		return
+	}
+
+	root = nestFitchLines(fls)
+
+	switch style {
+	case FitchSty:
+		sb.WriteString(`\begin{nd}` + "\n")
+
+		writeFitchSty(&sb, root, fls, 0)
+
+		sb.WriteString(`\end{nd}`)
+	case LplFitch:
+		hyps, body = newLplBlock(root, fls, 0)
+
+		sb.WriteString(`\fitchprf{` + hyps + "}\n{" + body + "}")
+	default:
+		panic("Invalid LaTeXStyle")
+	}
+
+	s = sb.String()
+
+	return
+}
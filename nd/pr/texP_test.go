@@ -0,0 +1,52 @@
+package pr
+
+import (
+	"testing"
+)
+
+func TestNewFitchLaTeX(t *testing.T) {
+	type testCase struct {
+		style    LaTeXStyle
+		expected string
+	}
+
+	var (
+		tcs      map[string]testCase
+		testName string
+		tc       testCase
+		prf      *Proof
+		s        string
+		met      bool
+	)
+
+	tcs = map[string]testCase{
+		"fitch.sty": {FitchSty, "\\begin{nd}\n" +
+			"  \\open\n" +
+			"    \\hypo {1} {A \\wedge B}\n" +
+			"    \\have {2} {A} \\by{\\textrm{$\\wedge$E}}{1}\n" +
+			"    \\have {3} {B} \\by{\\textrm{$\\wedge$E}}{1}\n" +
+			"    \\have {4} {B \\wedge A} \\by{\\textrm{$\\wedge$I}}{3, 2}\n" +
+			"  \\close\n" +
+			"  \\have {5} {(A \\wedge B) \\to (B \\wedge A)} \\by{\\textrm{$\\to$I}}{1, 4}\n" +
+			"  \\have {6} {C} \\by{\\textrm{PR}}{}\n" +
+			"  \\have {7} {C \\wedge ((A \\wedge B) \\to (B \\wedge A))} \\by{\\textrm{$\\wedge$I}}{6, 5}\n" +
+			"\\end{nd}"},
+		"lplfitch": {LplFitch, "\\fitchprf{}\n" +
+			"{\\subproof{\\pline[1.]{A \\wedge B}}{\\pline[2.]{A}[\\textrm{$\\wedge$E} 1] \\\\\n" +
+			"    \\pline[3.]{B}[\\textrm{$\\wedge$E} 1] \\\\\n" +
+			"    \\pline[4.]{B \\wedge A}[\\textrm{$\\wedge$I} 3, 2]} \\\\\n" +
+			"  \\pline[5.]{(A \\wedge B) \\to (B \\wedge A)}[\\textrm{$\\to$I} 1, 4] \\\\\n" +
+			"  \\pline[6.]{C}[\\textrm{PR}] \\\\\n" +
+			"  \\pline[7.]{C \\wedge ((A \\wedge B) \\to (B \\wedge A))}[\\textrm{$\\wedge$I} 6, 5]}"},
+	}
+
+	prf = newSwapProof()
+
+	for testName, tc = range tcs {
+		if s, met = NewFitchLaTeX(prf, tc.style); !met || s != tc.expected {
+			t.Errorf("\nFAILED %s: Expected\n%s\ngot\n%s", testName, tc.expected, s)
+		} else {
+			t.Logf("\nPASSED %s:\n%s", testName, s)
+		}
+	}
+}
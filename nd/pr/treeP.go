@@ -0,0 +1,293 @@
+package pr
+
+import (
+	"Deriver/fmla"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+type TreeNode struct {
+	ln    *Line       // The line concluded at the node.
+	Fmla  string      // The formula, represented as a string.
+	Rule  string      // The rule concluding the formula, empty for premises and assumptions.
+	Label uint        // The discharge label, shared by an assumption and the rule that discharges it.
+	Prems []*TreeNode // The subtrees concluding the premises of the rule.
+}
+
+// dischargedJ is the position, among j1, j2 and j3, of the assumption each rule discharges.
+var dischargedJ map[NDRule]int = map[NDRule]int{
+	ToIntro:     1,
+	NegIntro:    1,
+	ForAllIntro: 1,
+	BoxIntro:    1,
+	ExistsElim:  2,
+	DiamondElim: 2,
+}
+
+// worldJ is the position of a justification that only fixes the world of the conclusion.
+var worldJ map[NDRule]int = map[NDRule]int{
+	BoxElim: 2,
+}
+
+var supDigits = []rune("⁰¹²³⁴⁵⁶⁷⁸⁹")
+
+func supString(n uint) (s string) {
+	var (
+		r rune
+		b []rune
+	)
+
+	for _, r = range fmt.Sprintf("%d", n) {
+		b = append(b, supDigits[r-'0'])
+	}
+
+	s = string(b)
+
+	return
+}
+
+func buildTreeNode(ln *Line, lbls map[*Line]uint, next *uint) (tn *TreeNode) {
+	var (
+		dex, dexD, dexW int
+		jln, lnD        *Line
+		hasD            bool
+	)
+
+	// Reiteration only copies a formula across scopes, which trees have no need for.
+	if ln.rule == Reit {
+		tn = buildTreeNode(ln.j1, lbls, next)
+
+		return
+	}
+
+	tn = &TreeNode{
+		ln:   ln,
+		Fmla: fmla.GetWffString(ln.wff),
+	}
+
+	switch ln.rule {
+	case Premise:
+		return
+	case Assumption:
+		tn.Label = lbls[ln]
+
+		return
+	}
+
+	tn.Rule = ndRuleToName[ln.rule]
+
+	dexD, hasD = dischargedJ[ln.rule]
+
+	dexW = worldJ[ln.rule]
+
+	if hasD {
+		*next += 1
+
+		tn.Label = *next
+
+		lnD = []*Line{ln.j1, ln.j2, ln.j3}[dexD-1]
+
+		lbls[lnD] = tn.Label
+
+		defer delete(lbls, lnD)
+	}
+
+	for dex, jln = range []*Line{ln.j1, ln.j2, ln.j3} {
+		if jln == nil || (hasD && dex+1 == dexD) || dex+1 == dexW {
+			continue
+		}
+
+		tn.Prems = append(tn.Prems, buildTreeNode(jln, lbls, next))
+	}
+
+	return
+}
+
+// NewProofTree unfolds the justifications of the line meeting the head goal into a
+// natural deduction tree, labelling each discharged assumption with its discharging rule.
+func NewProofTree(prf *Proof) (root *TreeNode, met bool) {
+	var (
+		lnG  *Line
+		next uint
+	)
+
+	if _, lnG, met = prf.HeadGoalMet(); met {
+		root = buildTreeNode(lnG, map[*Line]uint{}, &next)
+	}
+
+	return
+}
+
+func writeBussproofs(sb *strings.Builder, tn *TreeNode) {
+	var (
+		tnP  *TreeNode
+		wffL string
+		lbl  string
+	)
+
+	wffL = fmla.GetWffLaTeX(tn.ln.wff, nil)
+
+	if tn.Rule == "" {
+		if 0 < tn.Label {
+			wffL = fmt.Sprintf("[%s]^{%d}", wffL, tn.Label)
+		}
+
+		sb.WriteString(`\AxiomC{$` + wffL + "$}\n")
+
+		return
+	}
+
+	if len(tn.Prems) == 0 {
+		sb.WriteString("\\AxiomC{}\n")
+	}
+
+	for _, tnP = range tn.Prems {
+		writeBussproofs(sb, tnP)
+	}
+
+	lbl = ruleToLaTeX(tn.ln.rule)
+
+	if 0 < tn.Label {
+		lbl += fmt.Sprintf("$^{%d}$", tn.Label)
+	}
+
+	sb.WriteString(`\RightLabel{\scriptsize ` + lbl + "}\n")
+
+	switch len(tn.Prems) {
+	case 0, 1:
+		sb.WriteString(`\UnaryInfC{$` + wffL + "$}\n")
+	case 2:
+		sb.WriteString(`\BinaryInfC{$` + wffL + "$}\n")
+	case 3:
+		sb.WriteString(`\TrinaryInfC{$` + wffL + "$}\n")
+	default:
+		panic("Too many premises for a rule.")
+	}
+}
+
+// NewProofTreeLaTeX renders the proof tree for the bussproofs package.
+func NewProofTreeLaTeX(prf *Proof) (s string, met bool) {
+	var (
+		root *TreeNode
+		sb   strings.Builder
+	)
+
+	if root, met = NewProofTree(prf); met {
+		sb.WriteString(`\begin{prooftree}` + "\n")
+
+		writeBussproofs(&sb, root)
+
+		sb.WriteString(`\end{prooftree}`)
+
+		s = sb.String()
+	}
+
+	return
+}
+
+func padRight(s string, width int) (sP string) {
+	sP = s + strings.Repeat(" ", max(0, width-utf8.RuneCountInString(s)))
+
+	return
+}
+
+// layoutTreeNode draws the subtree as rows of equal width, with the conclusion on the last row.
+func layoutTreeNode(tn *TreeNode) (rows []string, width int) {
+	var (
+		tnP                     *TreeNode
+		rowsP                   [][]string
+		widthsP                 []int
+		rowsC                   []string
+		dex, dexR, height       int
+		widthC, widthB, offsetC int
+		row, lbl                string
+	)
+
+	if tn.Rule == "" {
+		row = tn.Fmla
+
+		if 0 < tn.Label {
+			row = "[" + row + "]" + supString(tn.Label)
+		}
+
+		rows, width = []string{row}, utf8.RuneCountInString(row)
+
+		return
+	}
+
+	for _, tnP = range tn.Prems {
+		rowsC, widthC = layoutTreeNode(tnP)
+
+		rowsP, widthsP = append(rowsP, rowsC), append(widthsP, widthC)
+
+		height = max(height, len(rowsC))
+	}
+
+	// Set the premises side by side, aligned at their conclusions.
+	rowsC, widthC = make([]string, height), 0
+
+	for dex = range rowsP {
+		if 0 < dex {
+			widthC += 3
+		}
+
+		for dexR = range height {
+			row = strings.Repeat(" ", widthsP[dex])
+
+			if dexR >= height-len(rowsP[dex]) {
+				row = rowsP[dex][dexR-(height-len(rowsP[dex]))]
+			}
+
+			rowsC[dexR] = padRight(rowsC[dexR], widthC) + row
+		}
+
+		widthC += widthsP[dex]
+	}
+
+	widthB = max(widthC, utf8.RuneCountInString(tn.Fmla))
+
+	lbl = " " + tn.Rule
+
+	if 0 < tn.Label {
+		lbl += supString(tn.Label)
+	}
+
+	width = widthB + utf8.RuneCountInString(lbl)
+
+	offsetC = (widthB - widthC) / 2
+
+	for _, row = range rowsC {
+		rows = append(rows, padRight(strings.Repeat(" ", offsetC)+row, width))
+	}
+
+	rows = append(rows, padRight(strings.Repeat("-", widthB)+lbl, width))
+
+	offsetC = (widthB - utf8.RuneCountInString(tn.Fmla)) / 2
+
+	rows = append(rows, padRight(strings.Repeat(" ", offsetC)+tn.Fmla, width))
+
+	return
+}
+
+// NewProofTreeString renders the proof tree in plain text, with premises over a bar
+// labelled by the rule, and each discharged assumption bracketed with its label.
+func NewProofTreeString(prf *Proof) (s string, met bool) {
+	var (
+		root *TreeNode
+		rows []string
+		dex  int
+	)
+
+	if root, met = NewProofTree(prf); met {
+		rows, _ = layoutTreeNode(root)
+
+		for dex = range rows {
+			rows[dex] = strings.TrimRight(rows[dex], " ")
+		}
+
+		s = strings.Join(rows, "\n")
+	}
+
+	return
+}
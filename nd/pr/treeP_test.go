@@ -0,0 +1,74 @@
+package pr
+
+import (
+	"testing"
+)
+
+func TestNewProofTree(t *testing.T) {
+	var (
+		prf      *Proof
+		root     *TreeNode
+		tnTo, tn *TreeNode
+		s        string
+		met      bool
+		expected string
+	)
+
+	prf = newSwapProof()
+
+	if root, met = NewProofTree(prf); !met {
+		t.Fatalf("\nFAILED: Expected the head goal to be met.")
+	}
+
+	// →I discharges the assumption A∧B, and both of its uses carry its label.
+	tnTo = root.Prems[1]
+
+	if tnTo.Rule != "→I" || tnTo.Label != 1 || len(tnTo.Prems) != 1 {
+		t.Errorf("\nFAILED: Expected →I with label 1 and one premise, got %q with %d and %d.",
+			tnTo.Rule, tnTo.Label, len(tnTo.Prems))
+	}
+
+	for _, tn = range tnTo.Prems[0].Prems {
+		if tn.Prems[0].Fmla != "A∧B" || tn.Prems[0].Label != 1 {
+			t.Errorf("\nFAILED: Expected [A∧B]¹, got %q with label %d.", tn.Prems[0].Fmla, tn.Prems[0].Label)
+		}
+	}
+
+	expected = "    [A∧B]¹      [A∧B]¹\n" +
+		"    ------ ∧E   ------ ∧E\n" +
+		"      B           A\n" +
+		"    --------------------- ∧I\n" +
+		"             B∧A\n" +
+		"    ------------------------ →I¹\n" +
+		"C         (A∧B)→(B∧A)\n" +
+		"-------------------------------- ∧I\n" +
+		"        C∧((A∧B)→(B∧A))"
+
+	if s, met = NewProofTreeString(prf); !met || s != expected {
+		t.Errorf("\nFAILED: Expected the tree\n%s\ngot\n%s", expected, s)
+	} else {
+		t.Logf("\nPASSED:\n%s", s)
+	}
+
+	expected = "\\begin{prooftree}\n" +
+		"\\AxiomC{$C$}\n" +
+		"\\AxiomC{$[A \\wedge B]^{1}$}\n" +
+		"\\RightLabel{\\scriptsize \\textrm{$\\wedge$E}}\n" +
+		"\\UnaryInfC{$B$}\n" +
+		"\\AxiomC{$[A \\wedge B]^{1}$}\n" +
+		"\\RightLabel{\\scriptsize \\textrm{$\\wedge$E}}\n" +
+		"\\UnaryInfC{$A$}\n" +
+		"\\RightLabel{\\scriptsize \\textrm{$\\wedge$I}}\n" +
+		"\\BinaryInfC{$B \\wedge A$}\n" +
+		"\\RightLabel{\\scriptsize \\textrm{$\\to$I}$^{1}$}\n" +
+		"\\UnaryInfC{$(A \\wedge B) \\to (B \\wedge A)$}\n" +
+		"\\RightLabel{\\scriptsize \\textrm{$\\wedge$I}}\n" +
+		"\\BinaryInfC{$C \\wedge ((A \\wedge B) \\to (B \\wedge A))$}\n" +
+		"\\end{prooftree}"
+
+	if s, met = NewProofTreeLaTeX(prf); !met || s != expected {
+		t.Errorf("\nFAILED: Expected the bussproofs tree\n%s\ngot\n%s", expected, s)
+	} else {
+		t.Logf("\nPASSED:\n%s", s)
+	}
+}
@@ -0,0 +1,429 @@
+package sat
+
+import (
+	"Deriver/fmla"
+	"slices"
+)
+
+// A solver decides a clause set by conflict-driven clause learning:
+// it propagates units through two watched literals per clause, learns the first-UIP clause
+// of each conflict, picks variables by VSIDS activity with saved phases, and restarts on the Luby sequence.
+type solver struct {
+	clauses  [][]fmla.Literal // The clauses of the problem and then the learned ones, each watching its first two literals.
+	watches  [][]int          // The clauses watching each literal, by litIndex.
+	assigns  []int8           // The value of each variable: 1 if true, -1 if false, and 0 if unassigned.
+	level    []int            // The decision level at which each variable was assigned.
+	reason   []int            // The clause that implied each variable, or -1 for a decision.
+	phase    []bool           // The last value of each variable, which the next decision on it repeats.
+	activity []float64        // The VSIDS activity of each variable.
+	varInc   float64          // The amount by which the next conflict bumps activities.
+	seen     []bool           // Scratch marks for conflict analysis.
+	trail    []fmla.Literal   // The true literals, in the order assigned.
+	trailLim []int            // The trail's length at each decision.
+	qhead    int              // The trail index of the next literal to propagate.
+}
+
+// restartUnit is the number of conflicts per unit of the Luby sequence between restarts.
+const restartUnit int = 64
+
+func litIndex(lit fmla.Literal) (dex int) {
+	if dex = 2 * (lit.Var() - 1); lit < 0 {
+		dex += 1
+	}
+
+	return
+}
+
+func luby(n int) (l int) {
+	var (
+		size, seq int
+	)
+
+	// Find the finite subsequence that contains index n, and the size of that subsequence.
+	for size, seq = 1, 0; size < n+1; size, seq = 2*size+1, seq+1 {
+	}
+
+	for size-1 != n {
+		size, seq = (size-1)>>1, seq-1
+
+		n %= size
+	}
+
+	l = 1 << seq
+
+	return
+}
+
+func newSolver(numVars int) (slv *solver) {
+	slv = &solver{
+		watches:  make([][]int, 2*numVars),
+		assigns:  make([]int8, numVars+1),
+		level:    make([]int, numVars+1),
+		reason:   make([]int, numVars+1),
+		phase:    make([]bool, numVars+1),
+		activity: make([]float64, numVars+1),
+		varInc:   1,
+		seen:     make([]bool, numVars+1),
+	}
+
+	return
+}
+
+func (slv *solver) value(lit fmla.Literal) (val int8) {
+	if val = slv.assigns[lit.Var()]; lit < 0 {
+		val = -val
+	}
+
+	return
+}
+
+func (slv *solver) decisionLevel() (lvl int) {
+	lvl = len(slv.trailLim)
+
+	return
+}
+
+func (slv *solver) enqueue(lit fmla.Literal, from int) {
+	var (
+		v int = lit.Var()
+	)
+
+	if slv.assigns[v] = 1; lit < 0 {
+		slv.assigns[v] = -1
+	}
+
+	slv.level[v], slv.reason[v] = slv.decisionLevel(), from
+
+	slv.trail = append(slv.trail, lit)
+}
+
+// attach adds a clause of at least two literals, watching its first two, and returns its index.
+func (slv *solver) attach(cl []fmla.Literal) (ci int) {
+	ci = len(slv.clauses)
+
+	slv.clauses = append(slv.clauses, cl)
+
+	slv.watches[litIndex(cl[0])] = append(slv.watches[litIndex(cl[0])], ci)
+	slv.watches[litIndex(cl[1])] = append(slv.watches[litIndex(cl[1])], ci)
+
+	return
+}
+
+// addClause adds a clause of the problem before the search,
+// and reports false if it makes the problem unsatisfiable at once.
+func (slv *solver) addClause(cl fmla.Clause) (ok bool) {
+	var (
+		lits []fmla.Literal
+		lit  fmla.Literal
+	)
+
+	for _, lit = range cl {
+		switch {
+		case slices.Contains(lits, -lit):
+			// A tautology constrains nothing.
+			ok = true
+
+			return
+		case !slices.Contains(lits, lit):
+			lits = append(lits, lit)
+		}
+	}
+
+	switch len(lits) {
+	case 0:
+		ok = false
+	case 1:
+		switch slv.value(lits[0]) {
+		case 0:
+			slv.enqueue(lits[0], -1)
+
+			ok = true
+		case 1:
+			ok = true
+		}
+	default:
+		slv.attach(lits)
+
+		ok = true
+	}
+
+	return
+}
+
+// propagate assigns every literal that a clause forces, and returns the index of a falsified clause, or -1.
+func (slv *solver) propagate() (confl int) {
+	var (
+		p, falseLit fmla.Literal
+		ws, kept    []int
+		dex, k, ci  int
+		cl          []fmla.Literal
+		moved       bool
+	)
+
+	confl = -1
+
+	for confl == -1 && slv.qhead < len(slv.trail) {
+		p = slv.trail[slv.qhead]
+
+		slv.qhead += 1
+
+		falseLit = -p
+
+		ws, kept = slv.watches[litIndex(falseLit)], []int{}
+
+		for dex = 0; dex < len(ws); dex += 1 {
+			ci = ws[dex]
+
+			cl = slv.clauses[ci]
+
+			// Keep the false watch second.
+			if cl[0] == falseLit {
+				cl[0], cl[1] = cl[1], cl[0]
+			}
+
+			if slv.value(cl[0]) == 1 {
+				kept = append(kept, ci)
+
+				continue
+			}
+
+			// Look for a new literal to watch.
+			moved = false
+
+			for k = 2; k < len(cl); k += 1 {
+				if slv.value(cl[k]) != -1 {
+					cl[1], cl[k] = cl[k], cl[1]
+
+					slv.watches[litIndex(cl[1])] = append(slv.watches[litIndex(cl[1])], ci)
+
+					moved = true
+
+					break
+				}
+			}
+
+			if moved {
+				continue
+			}
+
+			kept = append(kept, ci)
+
+			if slv.value(cl[0]) == -1 {
+				confl = ci
+
+				kept = append(kept, ws[dex+1:]...)
+
+				break
+			}
+
+			slv.enqueue(cl[0], ci)
+		}
+
+		slv.watches[litIndex(falseLit)] = kept
+	}
+
+	return
+}
+
+func (slv *solver) bump(v int) {
+	var (
+		dex int
+	)
+
+	if slv.activity[v] += slv.varInc; 1e100 < slv.activity[v] {
+		for dex = range slv.activity {
+			slv.activity[dex] *= 1e-100
+		}
+
+		slv.varInc *= 1e-100
+	}
+}
+
+// analyze derives the first-UIP clause from a conflict, with the asserting literal first,
+// and returns it with the level to which to backjump.
+func (slv *solver) analyze(confl int) (learnt []fmla.Literal, btLevel int) {
+	var (
+		pathC, dex, v, maxDex int
+		p, q                  fmla.Literal
+		cl                    []fmla.Literal
+		start                 int
+	)
+
+	learnt, dex = []fmla.Literal{0}, len(slv.trail)-1
+
+	for {
+		cl = slv.clauses[confl]
+
+		// The first literal of a reason clause is the one it implied.
+		if start = 0; p != 0 {
+			start = 1
+		}
+
+		for _, q = range cl[start:] {
+			if v = q.Var(); !slv.seen[v] && 0 < slv.level[v] {
+				slv.seen[v] = true
+
+				slv.bump(v)
+
+				if slv.level[v] == slv.decisionLevel() {
+					pathC += 1
+				} else {
+					learnt = append(learnt, q)
+				}
+			}
+		}
+
+		for !slv.seen[slv.trail[dex].Var()] {
+			dex -= 1
+		}
+
+		p, dex = slv.trail[dex], dex-1
+
+		confl, slv.seen[p.Var()] = slv.reason[p.Var()], false
+
+		if pathC -= 1; pathC == 0 {
+			break
+		}
+	}
+
+	learnt[0] = -p
+
+	for _, q = range learnt[1:] {
+		slv.seen[q.Var()] = false
+	}
+
+	// Watch the literal of the highest level after the asserting one, so the clause is unit after backjumping.
+	for dex = 1; dex < len(learnt); dex += 1 {
+		if maxDex == 0 || slv.level[learnt[maxDex].Var()] < slv.level[learnt[dex].Var()] {
+			maxDex = dex
+		}
+	}
+
+	if maxDex != 0 {
+		learnt[1], learnt[maxDex] = learnt[maxDex], learnt[1]
+
+		btLevel = slv.level[learnt[1].Var()]
+	}
+
+	slv.varInc *= 1 / 0.95
+
+	return
+}
+
+// backjump undoes every assignment above the level.
+func (slv *solver) backjump(lvl int) {
+	var (
+		lit fmla.Literal
+	)
+
+	if slv.decisionLevel() <= lvl {
+		return
+	}
+
+	for _, lit = range slv.trail[slv.trailLim[lvl]:] {
+		slv.assigns[lit.Var()], slv.phase[lit.Var()] = 0, 0 < lit
+	}
+
+	slv.trail, slv.trailLim = slv.trail[:slv.trailLim[lvl]], slv.trailLim[:lvl]
+
+	slv.qhead = len(slv.trail)
+}
+
+// pickBranch returns the unassigned variable of the highest activity, in its saved phase, or 0 if none is left.
+func (slv *solver) pickBranch() (lit fmla.Literal) {
+	var (
+		v, best int
+	)
+
+	for v = 1; v < len(slv.assigns); v += 1 {
+		if slv.assigns[v] == 0 && (best == 0 || slv.activity[best] < slv.activity[v]) {
+			best = v
+		}
+	}
+
+	if lit = fmla.Literal(best); best != 0 && !slv.phase[best] {
+		lit = -lit
+	}
+
+	return
+}
+
+func (slv *solver) search() (sat bool) {
+	var (
+		confl, btLevel   int
+		learnt           []fmla.Literal
+		conflicts, limit int
+		restarts         int
+		next             fmla.Literal
+	)
+
+	limit = restartUnit * luby(restarts)
+
+	for {
+		if confl = slv.propagate(); confl != -1 {
+			if slv.decisionLevel() == 0 {
+				return
+			}
+
+			learnt, btLevel = slv.analyze(confl)
+
+			slv.backjump(btLevel)
+
+			if len(learnt) == 1 {
+				slv.enqueue(learnt[0], -1)
+			} else {
+				slv.enqueue(learnt[0], slv.attach(learnt))
+			}
+
+			conflicts += 1
+
+			continue
+		}
+
+		if limit <= conflicts {
+			restarts, conflicts = restarts+1, 0
+
+			limit = restartUnit * luby(restarts)
+
+			slv.backjump(0)
+
+			continue
+		}
+
+		if next = slv.pickBranch(); next == 0 {
+			sat = true
+
+			return
+		}
+
+		slv.trailLim = append(slv.trailLim, len(slv.trail))
+
+		slv.enqueue(next, -1)
+	}
+}
+
+// Solve decides whether the clauses are satisfiable and, if so,
+// returns a model with the value of each variable v at model[v-1].
+func Solve(cs *fmla.ClauseSet) (model []bool, sat bool) {
+	var (
+		slv *solver
+		cl  fmla.Clause
+		v   int
+	)
+
+	slv = newSolver(cs.NumVars)
+
+	for _, cl = range cs.Clauses {
+		if !slv.addClause(cl) {
+			return
+		}
+	}
+
+	if sat = slv.search(); sat {
+		for v = 1; v <= cs.NumVars; v += 1 {
+			model = append(model, slv.assigns[v] == 1)
+		}
+	}
+
+	return
+}
@@ -0,0 +1,156 @@
+package sat
+
+import (
+	"Deriver/fmla"
+	"Deriver/sem"
+	"testing"
+)
+
+// pigeonholes returns the clauses saying that n+1 pigeons sit in n holes, no two together.
+func pigeonholes(n int) (cs *fmla.ClauseSet) {
+	var (
+		p, q, h int
+		cl      fmla.Clause
+		sits    func(p, h int) (lit fmla.Literal)
+	)
+
+	sits = func(p, h int) (lit fmla.Literal) {
+		lit = fmla.Literal(p*n + h + 1)
+
+		return
+	}
+
+	cs = &fmla.ClauseSet{NumVars: (n + 1) * n}
+
+	for p = range n + 1 {
+		cl = fmla.Clause{}
+
+		for h = range n {
+			cl = append(cl, sits(p, h))
+		}
+
+		cs.Clauses = append(cs.Clauses, cl)
+	}
+
+	for h = range n {
+		for p = range n + 1 {
+			for q = p + 1; q < n+1; q += 1 {
+				cs.Clauses = append(cs.Clauses, fmla.Clause{-sits(p, h), -sits(q, h)})
+			}
+		}
+	}
+
+	return
+}
+
+func TestSolve(t *testing.T) {
+	var (
+		n     int
+		cs    *fmla.ClauseSet
+		model []bool
+		sat   bool
+		cl    fmla.Clause
+		lit   fmla.Literal
+		ok    bool
+	)
+
+	for n = 1; n <= 6; n += 1 {
+		if _, sat = Solve(pigeonholes(n)); sat {
+			t.Errorf("\nFAILED: Expected %d pigeons not to fit in %d holes.", n+1, n)
+		}
+
+		// Without its last pigeon, each problem is satisfiable.
+		cs = pigeonholes(n)
+
+		cs.Clauses = cs.Clauses[:n]
+		cs.Clauses = append(cs.Clauses, pigeonholes(n).Clauses[n+1:]...)
+
+		if model, sat = Solve(cs); !sat {
+			t.Errorf("\nFAILED: Expected %d pigeons to fit in %d holes.", n, n)
+
+			continue
+		}
+
+		for _, cl = range cs.Clauses {
+			ok = false
+
+			for _, lit = range cl {
+				ok = ok || model[lit.Var()-1] == (0 < lit)
+			}
+
+			if !ok {
+				t.Errorf("\nFAILED: The model of %d pigeons in %d holes falsifies %v.", n, n, cl)
+			}
+		}
+	}
+}
+
+func TestValid(t *testing.T) {
+	type testCase struct {
+		goal  string
+		prems []string
+		valid bool
+	}
+
+	var (
+		tcs   []testCase
+		tc    testCase
+		goal  *fmla.WffTree
+		prems []*fmla.WffTree
+		wff   *fmla.WffTree
+		s     string
+		val   sem.Valuation
+		valid bool
+		tv    bool
+		err   error
+	)
+
+	tcs = []testCase{
+		{"P∨¬P", nil, true},
+		{"((P→Q)→P)→P", nil, true},
+		{"Q", []string{"P→Q", "P"}, true},
+		{"P", []string{"P→Q", "Q"}, false},
+		{"(P∧Q)∨(¬P∧¬Q)", []string{"P↔Q"}, true},
+		{"R", []string{"P∨Q", "P→R", "Q→S"}, false},
+		{"⊥", []string{"P", "¬P"}, true},
+	}
+
+	for _, tc = range tcs {
+		prems = []*fmla.WffTree{}
+
+		for _, s = range tc.prems {
+			wff, _ = fmla.ParseWff(s)
+
+			prems = append(prems, wff)
+		}
+
+		goal, _ = fmla.ParseWff(tc.goal)
+
+		if val, valid, err = Valid(goal, prems...); err != nil || valid != tc.valid {
+			t.Errorf("\nFAILED: Expected %v ⊨ %s to be %t, got %t (%v).", tc.prems, tc.goal, tc.valid, valid, err)
+
+			continue
+		}
+
+		if !valid {
+			if tv, err = sem.Evaluate(goal, val); err != nil || tv {
+				t.Errorf("\nFAILED: Expected %v to falsify %s.", val, tc.goal)
+			}
+		}
+
+		t.Logf("\nPASSED: %v ⊨ %s is %t.", tc.prems, tc.goal, valid)
+	}
+
+	if _, _, err = Valid(goal, fmla.NewAtomicWff('F', 'a')); err == nil {
+		t.Errorf("\nFAILED: Expected an error for a first-order premise.")
+	}
+
+	// The premises may share a backing array with the caller's slice, which must be left alone.
+	prems = []*fmla.WffTree{fmla.NewAtomicWff('A'), fmla.NewAtomicWff('B'), fmla.NewAtomicWff('C')}
+
+	goal = fmla.NewCompositeWff(fmla.Wedge, prems[0], prems[1], 0, 0)
+
+	if _, _, err = Valid(goal, prems[:2]...); err != nil || fmla.GetWffString(prems[2]) != "C" {
+		t.Errorf("\nFAILED: Expected the caller's C to be left alone, got %s.", fmla.GetWffString(prems[2]))
+	}
+}
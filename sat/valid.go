@@ -0,0 +1,62 @@
+package sat
+
+import (
+	"Deriver/fmla"
+	"Deriver/sem"
+	"fmt"
+	"slices"
+)
+
+// Satisfiable decides whether the propositional formulae are true together under some valuation,
+// by solving their Tseitin clauses, and returns such a valuation if so.
+func Satisfiable(wffs ...*fmla.WffTree) (val sem.Valuation, sat bool, err error) {
+	var (
+		wff   *fmla.WffTree
+		cs    *fmla.ClauseSet
+		model []bool
+		dex   int
+		atom  *fmla.WffTree
+		pred  fmla.Predicate
+	)
+
+	for _, wff = range wffs {
+		if !sem.IsPropositional(wff) {
+			err = fmt.Errorf("sat: %s is not propositional", fmla.GetWffString(wff))
+
+			return
+		}
+	}
+
+	cs = fmla.Tseitin(wffs...)
+
+	if model, sat = Solve(cs); !sat {
+		return
+	}
+
+	val = sem.Valuation{}
+
+	for dex, atom = range cs.Atoms {
+		if atom != nil {
+			pred, _, _ = fmla.GetWffPredAndArgs(atom)
+
+			val[pred] = model[dex]
+		}
+	}
+
+	return
+}
+
+// Valid decides whether the goal is true under every valuation that makes the premises true.
+// If not, it returns a valuation under which the premises are true and the goal false.
+func Valid(goal *fmla.WffTree, prems ...*fmla.WffTree) (val sem.Valuation, valid bool, err error) {
+	var (
+		sat bool
+	)
+
+	// A new slice, so that the premises' backing array, which may be the caller's, is left alone.
+	val, sat, err = Satisfiable(slices.Concat(prems, []*fmla.WffTree{fmla.NewCompositeWff(fmla.Neg, goal, nil, 0, 0)})...)
+
+	valid = err == nil && !sat
+
+	return
+}
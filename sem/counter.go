@@ -0,0 +1,502 @@
+package sem
+
+import (
+	"Deriver/fmla"
+	"fmt"
+	"math/bits"
+	"slices"
+	"strings"
+)
+
+// A Countermodel makes the premises of an argument true and its goal false.
+// Exactly one of its fields is set, by the kind of argument it refutes.
+type Countermodel struct {
+	Val    Valuation       // For a propositional argument.
+	Interp *Interpretation // For a first-order argument.
+	Kripke *KripkeModel    // For a modal argument, at world 0.
+}
+
+// The search for a countermodel tries domains, or sets of worlds, of up to MaxCounterSize elements,
+// and gives up after MaxCounterTries candidates.
+const (
+	MaxCounterSize  uint = 3
+	MaxCounterTries uint = 1 << 20
+)
+
+// A signature holds the non-logical symbols of an argument and their arities.
+type signature struct {
+	args  []fmla.Argument          // The argument constants and free argument variables.
+	funcs []fmla.Function          // The function symbols.
+	preds []fmla.Predicate         // The predicates, apart from ⊤, ⊥, = and bound predicate variables.
+	arity map[rune]uint            // The arity of each function symbol and predicate.
+	atoms map[string]*fmla.WffTree // The atomic formulae, by their strings.
+}
+
+func (cm *Countermodel) String() (s string) {
+	var (
+		ss []string
+		pc fmla.Predicate
+	)
+
+	switch {
+	case cm.Val != nil:
+		for _, pc = range sortedKeys(cm.Val, func(pA, pB fmla.Predicate) (comp int) {
+			comp = int(fmla.PredOrdinal(pA)) - int(fmla.PredOrdinal(pB))
+
+			return
+		}) {
+			ss = append(ss, fmt.Sprintf("%s = %s", pc, truthString(cm.Val[pc])))
+		}
+
+		s = strings.Join(ss, ", ")
+	case cm.Interp != nil:
+		s = cm.Interp.String()
+	case cm.Kripke != nil:
+		s = cm.Kripke.String()
+	}
+
+	return
+}
+
+func collectTermSymbols(t *fmla.Term, sig *signature) {
+	var (
+		fun  fmla.Function
+		subs []*fmla.Term
+		sub  *fmla.Term
+		ok   bool
+	)
+
+	if fun, subs, ok = fmla.GetTermFunc(t); !ok {
+		return
+	}
+
+	if _, ok = sig.arity[rune(fun)]; !ok {
+		sig.funcs, sig.arity[rune(fun)] = append(sig.funcs, fun), uint(len(subs))
+	}
+
+	for _, sub = range subs {
+		collectTermSymbols(sub, sig)
+	}
+}
+
+func collectSignature(wffs ...*fmla.WffTree) (sig *signature) {
+	var (
+		wff, sub *fmla.WffTree
+		pvs      []fmla.Predicate
+		acs, avs []fmla.Argument
+		arg      fmla.Argument
+		free     map[fmla.Predicate]bool
+		pred     fmla.Predicate
+		pv       fmla.Predicate
+		ts       []*fmla.Term
+		t        *fmla.Term
+		ok       bool
+	)
+
+	sig = &signature{arity: map[rune]uint{}, atoms: map[string]*fmla.WffTree{}}
+
+	free = map[fmla.Predicate]bool{}
+
+	for _, wff = range wffs {
+		_, acs = fmla.GetConstants(wff)
+
+		pvs, avs = fmla.GetFreeVariables(wff)
+
+		for _, arg = range append(acs, avs...) {
+			if !slices.Contains(sig.args, arg) {
+				sig.args = append(sig.args, arg)
+			}
+		}
+
+		for _, pv = range pvs {
+			free[pv] = true
+		}
+	}
+
+	for _, wff = range wffs {
+		for _, sub = range fmla.AllSubformulae(wff) {
+			if fmla.GetWffKind(sub) != fmla.Atomic {
+				continue
+			}
+
+			sig.atoms[fmla.GetWffString(sub)] = sub
+
+			pred, ts, _ = fmla.GetWffPredAndTerms(sub)
+
+			for _, t = range ts {
+				collectTermSymbols(t, sig)
+			}
+
+			if pred == fmla.Top || pred == fmla.Bot || pred == fmla.Equals || (fmla.IsPredVar(pred) && !free[pred]) {
+				continue
+			}
+
+			if _, ok = sig.arity[rune(pred)]; !ok {
+				sig.preds, sig.arity[rune(pred)] = append(sig.preds, pred), uint(len(ts))
+			}
+		}
+	}
+
+	return
+}
+
+// nextDigits advances the digits like an odometer, with each digit below its radix,
+// and reports whether they didn't wrap around to all zeroes.
+func nextDigits(ds, radices []uint) (ok bool) {
+	var (
+		dex int
+	)
+
+	for dex = range ds {
+		if ds[dex]+1 < radices[dex] {
+			ds[dex] += 1
+
+			ok = true
+
+			return
+		}
+
+		ds[dex] = 0
+	}
+
+	return
+}
+
+// tuples returns every k-tuple of elements of {0, ..., n-1}, in lexicographic order.
+func tuples(n, k uint) (tps [][]uint) {
+	var (
+		ds, radices []uint
+		dex         int
+	)
+
+	ds, radices = make([]uint, k), make([]uint, k)
+
+	for dex = range radices {
+		radices[dex] = n
+	}
+
+	for {
+		tps = append(tps, append([]uint{}, ds...))
+
+		if !nextDigits(ds, radices) {
+			break
+		}
+	}
+
+	return
+}
+
+func sortedKeys[K comparable, V any](m map[K]V, less func(kA, kB K) (comp int)) (ks []K) {
+	var (
+		k K
+	)
+
+	for k = range m {
+		ks = append(ks, k)
+	}
+
+	slices.SortFunc(ks, less)
+
+	return
+}
+
+// refutes reports whether eval makes every premise true and the goal false.
+func refutes(eval func(wff *fmla.WffTree) (tv bool, err error), goal *fmla.WffTree, prems []*fmla.WffTree) (is bool, err error) {
+	var (
+		prem *fmla.WffTree
+		tv   bool
+	)
+
+	if tv, err = eval(goal); err != nil || tv {
+		return
+	}
+
+	for _, prem = range prems {
+		if tv, err = eval(prem); err != nil || !tv {
+			return
+		}
+	}
+
+	is = true
+
+	return
+}
+
+func findValuation(goal *fmla.WffTree, prems []*fmla.WffTree) (val Valuation, found bool) {
+	var (
+		atoms  []fmla.Predicate
+		n      uint
+		dexA   int
+		lenA   int
+		failed error
+		eval   func(wff *fmla.WffTree) (tv bool, err error)
+	)
+
+	if atoms = collectAtoms(append([]*fmla.WffTree{goal}, prems...)...); MaxTableAtoms < uint(len(atoms)) {
+		return
+	}
+
+	lenA = len(atoms)
+
+	eval = func(wff *fmla.WffTree) (tv bool, err error) {
+		tv, err = Evaluate(wff, val)
+
+		return
+	}
+
+	for n = range uint(1) << lenA {
+		val = Valuation{}
+
+		for dexA = range lenA {
+			val[atoms[dexA]] = n&(1<<(lenA-1-dexA)) == 0
+		}
+
+		if found, failed = refutes(eval, goal, prems); found || failed != nil {
+			break
+		}
+	}
+
+	if !found {
+		val = nil
+	}
+
+	return
+}
+
+func findInterpretation(goal *fmla.WffTree, prems []*fmla.WffTree) (interp *Interpretation, found bool) {
+	var (
+		sig         *signature
+		n, tries, k uint
+		radices, ds []uint
+		tpsByArity  map[uint][][]uint
+		tp          []uint
+		dex         int
+		arg         fmla.Argument
+		fun         fmla.Function
+		pred        fmla.Predicate
+		total       uint
+		ok          bool
+		failed      error
+		eval        func(wff *fmla.WffTree) (tv bool, err error)
+		build       func()
+	)
+
+	sig = collectSignature(append([]*fmla.WffTree{goal}, prems...)...)
+
+	eval = func(wff *fmla.WffTree) (tv bool, err error) {
+		tv, err = interp.Evaluate(wff)
+
+		return
+	}
+
+	// Build the interpretation that the digits encode: the elements of the arguments,
+	// then the values of each function, then the extension of each predicate.
+	build = func() {
+		interp, dex = NewInterpretation(n), 0
+
+		for _, arg = range sig.args {
+			interp.consts[arg], dex = ds[dex], dex+1
+		}
+
+		for _, fun = range sig.funcs {
+			interp.funcs[fun] = map[string]uint{}
+
+			for _, tp = range tpsByArity[sig.arity[rune(fun)]] {
+				interp.funcs[fun][tupleKey(tp)], dex = ds[dex], dex+1
+			}
+		}
+
+		for _, pred = range sig.preds {
+			interp.exts[pred] = map[string]bool{}
+
+			for _, tp = range tpsByArity[sig.arity[rune(pred)]] {
+				interp.exts[pred][tupleKey(tp)], dex = ds[dex] == 1, dex+1
+			}
+		}
+	}
+
+	for n = 1; n <= MaxCounterSize; n += 1 {
+		tpsByArity, radices, total = map[uint][][]uint{}, []uint{}, 1
+
+		for _, k = range sig.arity {
+			if _, ok = tpsByArity[k]; !ok {
+				tpsByArity[k] = tuples(n, k)
+			}
+		}
+
+		for range sig.args {
+			radices = append(radices, n)
+		}
+
+		for _, fun = range sig.funcs {
+			for range tpsByArity[sig.arity[rune(fun)]] {
+				radices = append(radices, n)
+			}
+		}
+
+		for _, pred = range sig.preds {
+			for range tpsByArity[sig.arity[rune(pred)]] {
+				radices = append(radices, 2)
+			}
+		}
+
+		// Larger domains only have more candidates, so give up once they exceed the budget.
+		for _, k = range radices {
+			if total *= k; MaxCounterTries-tries < total {
+				return
+			}
+		}
+
+		ds = make([]uint, len(radices))
+
+		for {
+			build()
+
+			tries += 1
+
+			if found, failed = refutes(eval, goal, prems); found || failed != nil {
+				return
+			}
+
+			if !nextDigits(ds, radices) {
+				break
+			}
+		}
+	}
+
+	interp = nil
+
+	return
+}
+
+func findKripkeModel(fp FrameProperty, goal *fmla.WffTree, prems []*fmla.WffTree) (km *KripkeModel, found bool) {
+	var (
+		atoms             []*fmla.WffTree
+		atom              *fmla.WffTree
+		n, tries, shift   uint
+		accDs, accRadices []uint
+		valDs, valRadices []uint
+		w, v              uint
+		dex               int
+		failed            error
+		eval              func(wff *fmla.WffTree) (tv bool, err error)
+	)
+
+	for _, atom = range collectSignature(append([]*fmla.WffTree{goal}, prems...)...).atoms {
+		if !isTopOrBot(atom) && !isReflexiveIdentity(atom) {
+			atoms = append(atoms, atom)
+		}
+	}
+
+	eval = func(wff *fmla.WffTree) (tv bool, err error) {
+		tv, err = km.Evaluate(wff, 0)
+
+		return
+	}
+
+	for n = 1; n <= MaxCounterSize; n += 1 {
+		accDs, accRadices = make([]uint, n*n), make([]uint, n*n)
+
+		valDs, valRadices = make([]uint, n*uint(len(atoms))), make([]uint, n*uint(len(atoms)))
+
+		for dex = range accRadices {
+			accRadices[dex] = 2
+		}
+
+		for dex = range valRadices {
+			valRadices[dex] = 2
+		}
+
+		// Larger sets of worlds only have more candidates, so give up once they exceed the budget.
+		// The exponent is checked before shifting, since a shift of bits.UintSize or more gives 0.
+		if shift = n*n + n*uint(len(atoms)); uint(bits.Len(MaxCounterTries)) <= shift || MaxCounterTries-tries < 1<<shift {
+			break
+		}
+
+		for {
+			km = NewKripkeModel(n)
+
+			for dex = range accDs {
+				if w, v = uint(dex)/n, uint(dex)%n; accDs[dex] == 1 {
+					km.SetAccess(World(w), World(v))
+				}
+			}
+
+			for km.HasFrame(fp) {
+				for w = range n {
+					km.val[w] = map[string]bool{}
+				}
+
+				for dex = range valDs {
+					if valDs[dex] == 1 {
+						_ = km.SetValue(World(uint(dex)/uint(len(atoms))), atoms[uint(dex)%uint(len(atoms))], true)
+					}
+				}
+
+				if tries += 1; MaxCounterTries < tries {
+					km = nil
+
+					return
+				}
+
+				if found, failed = refutes(eval, goal, prems); found || failed != nil {
+					return
+				}
+
+				// Every valuation on this frame fails, so move on to the next frame.
+				if !nextDigits(valDs, valRadices) {
+					break
+				}
+			}
+
+			if !nextDigits(accDs, accRadices) {
+				break
+			}
+		}
+	}
+
+	km = nil
+
+	return
+}
+
+// FindCountermodel searches for a small model in which the premises are true and the goal false:
+// a valuation for a propositional argument, a Kripke model on a frame with the properties fp
+// for a modal argument, and a first-order interpretation otherwise.
+// It reports false when it finds none within its bounds, which doesn't make the argument valid.
+func FindCountermodel(fp FrameProperty, goal *fmla.WffTree, prems ...*fmla.WffTree) (cm *Countermodel, found bool) {
+	var (
+		wffs          []*fmla.WffTree
+		wff, sub      *fmla.WffTree
+		isProp, modal bool
+	)
+
+	wffs, isProp = append([]*fmla.WffTree{goal}, prems...), true
+
+	for _, wff = range wffs {
+		isProp = isProp && IsPropositional(wff)
+
+		for _, sub = range fmla.AllSubformulae(wff) {
+			if fmla.GetWffKind(sub) == fmla.Unary && fmla.GetWffMop(sub) != fmla.Neg {
+				modal = true
+			}
+		}
+	}
+
+	cm = &Countermodel{}
+
+	switch {
+	case isProp:
+		cm.Val, found = findValuation(goal, prems)
+	case modal:
+		cm.Kripke, found = findKripkeModel(fp, goal, prems)
+	default:
+		cm.Interp, found = findInterpretation(goal, prems)
+	}
+
+	if !found {
+		cm = nil
+	}
+
+	return
+}
@@ -0,0 +1,88 @@
+package sem
+
+import (
+	"Deriver/fmla"
+	"testing"
+)
+
+func TestFindCountermodel(t *testing.T) {
+	type testCase struct {
+		fp    FrameProperty
+		goal  string
+		prems []string
+		found bool
+	}
+
+	var (
+		tcs   []testCase
+		tc    testCase
+		goal  *fmla.WffTree
+		prems []*fmla.WffTree
+		wff   *fmla.WffTree
+		s     string
+		cm    *Countermodel
+		found bool
+		err   error
+	)
+
+	tcs = []testCase{
+		{0, "Q", []string{"P→Q", "Q→P"}, true},
+		{0, "P∨¬P", nil, false},
+		{0, "∀x∀yFxy", []string{"∀xFxx"}, true},
+		{0, "∃y∀xFxy", []string{"∀x∃yFxy"}, true},
+		{0, "∀x∃yFxy", []string{"∃y∀xFxy"}, false},
+		{0, "a=b", []string{"Fa", "Fb"}, true},
+		{0, "P", []string{"□P"}, true},
+		{Reflexive, "P", []string{"□P"}, false},
+		{Serial, "◇P", []string{"□P"}, false},
+		{Serial | Transitive | Symmetric, "□◇P", []string{"P"}, false},
+		{Transitive, "□◇P", []string{"P"}, true},
+	}
+
+	for _, tc = range tcs {
+		prems = []*fmla.WffTree{}
+
+		for _, s = range tc.prems {
+			if wff, err = fmla.ParseWff(s); err != nil {
+				t.Fatalf("\nFAILED: %v", err)
+			}
+
+			prems = append(prems, wff)
+		}
+
+		if goal, err = fmla.ParseWff(tc.goal); err != nil {
+			t.Fatalf("\nFAILED: %v", err)
+		}
+
+		if cm, found = FindCountermodel(tc.fp, goal, prems...); found != tc.found {
+			t.Errorf("\nFAILED: Expected a countermodel to %v ⊢ %s to be found: %t.", tc.prems, tc.goal, tc.found)
+		} else if found {
+			t.Logf("\nPASSED: %v ⊬ %s:\n%s", tc.prems, tc.goal, cm)
+		} else {
+			t.Logf("\nPASSED: %v ⊢ %s has no small countermodel.", tc.prems, tc.goal)
+		}
+	}
+}
+
+func TestFindCountermodelBudget(t *testing.T) {
+	var (
+		goal  *fmla.WffTree
+		dex   uint
+		found bool
+	)
+
+	// With 64 atoms, even one world has 2^65 candidates, more than a uint can count.
+	goal = fmla.NewAtomicWff(fmla.NthPredConst(0))
+
+	for dex = 1; dex < 64; dex += 1 {
+		goal = fmla.NewCompositeWff(fmla.Wedge, goal, fmla.NewAtomicWff(fmla.NthPredConst(dex)), 0, 0)
+	}
+
+	goal = fmla.NewCompositeWff(fmla.Box, goal, nil, 0, 0)
+
+	if _, found = FindCountermodel(0, goal); found {
+		t.Errorf("\nFAILED: Expected the search to give up on 64 atoms.")
+	} else {
+		t.Logf("\nPASSED: The search gave up on 64 atoms.")
+	}
+}
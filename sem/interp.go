@@ -0,0 +1,427 @@
+package sem
+
+import (
+	"Deriver/fmla"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// An Interpretation is a first-order structure over the domain {0, ..., n-1}.
+// Free argument and predicate variables are interpreted like constants.
+type Interpretation struct {
+	size   uint
+	consts map[fmla.Argument]uint             // The element that each argument denotes.
+	funcs  map[fmla.Function]map[string]uint  // Each function's value at each tuple, keyed by tupleKey.
+	exts   map[fmla.Predicate]map[string]bool // The tuples in each predicate's extension, keyed by tupleKey.
+}
+
+func NewInterpretation(n uint) (interp *Interpretation) {
+	if n == 0 {
+		panic("Invalid domain size")
+	}
+
+	interp = &Interpretation{
+		size:   n,
+		consts: map[fmla.Argument]uint{},
+		funcs:  map[fmla.Function]map[string]uint{},
+		exts:   map[fmla.Predicate]map[string]bool{},
+	}
+
+	return
+}
+
+func tupleKey(elts []uint) (key string) {
+	key = fmt.Sprint(elts)
+
+	return
+}
+
+func (interp *Interpretation) Size() (n uint) {
+	n = interp.size
+
+	return
+}
+
+func (interp *Interpretation) hasElements(elts ...uint) (has bool) {
+	has = !slices.ContainsFunc(elts, func(e uint) (out bool) {
+		out = interp.size <= e
+
+		return
+	})
+
+	return
+}
+
+// Assign makes the argument denote the element.
+func (interp *Interpretation) Assign(arg fmla.Argument, e uint) (err error) {
+	if !interp.hasElements(e) {
+		err = fmt.Errorf("sem: %d is not in the domain", e)
+
+		return
+	}
+
+	interp.consts[arg] = e
+
+	return
+}
+
+// SetFunc makes the function symbol map the tuple of elements to the element.
+func (interp *Interpretation) SetFunc(fun fmla.Function, tp []uint, e uint) (err error) {
+	var (
+		arity uint
+	)
+
+	_, _, arity = fmla.SplitFunction(fun)
+
+	switch {
+	case uint(len(tp)) != arity:
+		err = fmt.Errorf("sem: %s takes %d arguments, not %d", fun, arity, len(tp))
+	case !interp.hasElements(append(slices.Clone(tp), e)...):
+		err = fmt.Errorf("sem: %v ↦ %d is not in the domain", tp, e)
+	default:
+		if interp.funcs[fun] == nil {
+			interp.funcs[fun] = map[string]uint{}
+		}
+
+		interp.funcs[fun][tupleKey(tp)] = e
+	}
+
+	return
+}
+
+// SetExtension makes the tuples of elements the extension of the predicate,
+// which may hold tuples of several lengths, for its uses at several arities.
+// A 0-place predicate is true just when its extension holds the empty tuple.
+// The extensions of ⊤, ⊥ and = are fixed, with = as identity.
+func (interp *Interpretation) SetExtension(pred fmla.Predicate, tps ...[]uint) (err error) {
+	var (
+		ext map[string]bool
+		tp  []uint
+	)
+
+	if pred == fmla.Top || pred == fmla.Bot || pred == fmla.Equals {
+		err = fmt.Errorf("sem: the extension of %s is fixed", pred)
+
+		return
+	}
+
+	ext = map[string]bool{}
+
+	for _, tp = range tps {
+		if !interp.hasElements(tp...) {
+			err = fmt.Errorf("sem: %v is not in the domain", tp)
+
+			return
+		}
+
+		ext[tupleKey(tp)] = true
+	}
+
+	interp.exts[pred] = ext
+
+	return
+}
+
+func (interp *Interpretation) evalTerm(t *fmla.Term, asg map[fmla.Argument]uint) (e uint, err error) {
+	var (
+		arg  fmla.Argument
+		fun  fmla.Function
+		subs []*fmla.Term
+		sub  *fmla.Term
+		elts []uint
+		ok   bool
+	)
+
+	if arg, ok = fmla.GetTermArg(t); ok {
+		if e, ok = asg[arg]; !ok {
+			if e, ok = interp.consts[arg]; !ok {
+				err = fmt.Errorf("sem: the interpretation omits %s", arg)
+			}
+		}
+
+		return
+	}
+
+	fun, subs, _ = fmla.GetTermFunc(t)
+
+	for _, sub = range subs {
+		if e, err = interp.evalTerm(sub, asg); err != nil {
+			return
+		}
+
+		elts = append(elts, e)
+	}
+
+	if e, ok = interp.funcs[fun][tupleKey(elts)]; !ok {
+		err = fmt.Errorf("sem: the interpretation omits %s", fmla.GetTermString(t))
+	}
+
+	return
+}
+
+func (interp *Interpretation) eval(wff *fmla.WffTree, asg map[fmla.Argument]uint) (tv bool, err error) {
+	var (
+		pred       fmla.Predicate
+		ts         []*fmla.Term
+		t          *fmla.Term
+		elts       []uint
+		e          uint
+		qua        fmla.Symbol
+		pv         fmla.Predicate
+		av         fmla.Argument
+		subL, subR *fmla.WffTree
+		tvL, tvR   bool
+		old        uint
+		had        bool
+	)
+
+	switch fmla.GetWffKind(wff) {
+	case fmla.Atomic:
+		pred, ts, _ = fmla.GetWffPredAndTerms(wff)
+
+		for _, t = range ts {
+			if e, err = interp.evalTerm(t, asg); err != nil {
+				return
+			}
+
+			elts = append(elts, e)
+		}
+
+		switch pred {
+		case fmla.Top:
+			tv = true
+		case fmla.Bot:
+			tv = false
+		case fmla.Equals:
+			tv = elts[0] == elts[1]
+		default:
+			tv = interp.exts[pred][tupleKey(elts)]
+		}
+	case fmla.Unary:
+		if fmla.GetWffMop(wff) != fmla.Neg {
+			err = fmt.Errorf("sem: %s is modal", fmla.GetWffString(wff))
+
+			return
+		}
+
+		subL, _ = fmla.GetWffSubformulae(wff)
+
+		if tvL, err = interp.eval(subL, asg); err == nil {
+			tv = !tvL
+		}
+	case fmla.Binary:
+		subL, subR = fmla.GetWffSubformulae(wff)
+
+		if tvL, err = interp.eval(subL, asg); err != nil {
+			return
+		}
+
+		if tvR, err = interp.eval(subR, asg); err != nil {
+			return
+		}
+
+		switch fmla.GetWffMop(wff) {
+		case fmla.Wedge:
+			tv = tvL && tvR
+		case fmla.Vee:
+			tv = tvL || tvR
+		case fmla.To:
+			tv = !tvL || tvR
+		case fmla.Iff:
+			tv = tvL == tvR
+		}
+	case fmla.Quantified:
+		subL, _ = fmla.GetWffSubformulae(wff)
+
+		if qua, pv, av = fmla.GetWffMopAndVars(wff); pv != 0 {
+			tv, err = interp.evalPredQuantifier(qua, pv, subL, asg)
+
+			return
+		}
+
+		// Restore any outer binding of the same variable once its scope ends.
+		old, had = asg[av]
+
+		tv = qua == fmla.ForAll
+
+		for e = range interp.size {
+			asg[av] = e
+
+			if tvL, err = interp.eval(subL, asg); err != nil || tvL != tv {
+				tv = !tv && err == nil
+
+				break
+			}
+		}
+
+		if had {
+			asg[av] = old
+		} else {
+			delete(asg, av)
+		}
+	}
+
+	return
+}
+
+// MaxQuantTuples caps the tuples over which a predicate variable ranges,
+// since it ranges over the 2^n extensions built from n tuples.
+const MaxQuantTuples uint = 16
+
+// evalPredQuantifier evaluates a quantifier over the predicate variable pv,
+// which ranges over every extension of tuples of the lengths at which the scope sub uses it.
+func (interp *Interpretation) evalPredQuantifier(qua fmla.Symbol, pv fmla.Predicate, sub *fmla.WffTree,
+	asg map[fmla.Argument]uint) (tv bool, err error) {
+	var (
+		atom     *fmla.WffTree
+		pred     fmla.Predicate
+		ts       []*fmla.Term
+		arities  []uint
+		k, n     uint
+		tps      [][]uint
+		dex      int
+		ext, old map[string]bool
+		had, tvS bool
+	)
+
+	for _, atom = range fmla.AllSubformulae(sub) {
+		if pred, ts, _ = fmla.GetWffPredAndTerms(atom); fmla.GetWffKind(atom) == fmla.Atomic && pred == pv &&
+			!slices.Contains(arities, uint(len(ts))) {
+			arities = append(arities, uint(len(ts)))
+		}
+	}
+
+	for _, k = range arities {
+		tps = append(tps, tuples(interp.size, k)...)
+	}
+
+	if MaxQuantTuples < uint(len(tps)) {
+		err = fmt.Errorf("sem: %s ranges over %d tuples, more than the %d allowed", pv, len(tps), MaxQuantTuples)
+
+		return
+	}
+
+	// Restore any outer binding of the same variable once its scope ends.
+	old, had = interp.exts[pv]
+
+	tv = qua == fmla.ForAll
+
+	for n = range uint(1) << len(tps) {
+		ext = map[string]bool{}
+
+		for dex = range tps {
+			if n&(1<<dex) != 0 {
+				ext[tupleKey(tps[dex])] = true
+			}
+		}
+
+		interp.exts[pv] = ext
+
+		if tvS, err = interp.eval(sub, asg); err != nil || tvS != tv {
+			tv = !tv && err == nil
+
+			break
+		}
+	}
+
+	if had {
+		interp.exts[pv] = old
+	} else {
+		delete(interp.exts, pv)
+	}
+
+	return
+}
+
+// Evaluate returns the truth value of a non-modal formula in the interpretation,
+// including quantifiers over predicate variables, which range over every extension on the domain.
+func (interp *Interpretation) Evaluate(wff *fmla.WffTree) (tv bool, err error) {
+	tv, err = interp.eval(wff, map[fmla.Argument]uint{})
+
+	return
+}
+
+func tupleString(key string) (s string) {
+	s = "(" + strings.ReplaceAll(strings.Trim(key, "[]"), " ", ", ") + ")"
+
+	return
+}
+
+func (interp *Interpretation) String() (s string) {
+	var (
+		ss     []string
+		elts   []string
+		e      uint
+		arg    fmla.Argument
+		fun    fmla.Function
+		pred   fmla.Predicate
+		key    string
+		keys   []string
+		tv, ok bool
+		byRune func(rA, rB rune) (comp int)
+	)
+
+	byRune = func(rA, rB rune) (comp int) {
+		comp = int(rA) - int(rB)
+
+		return
+	}
+
+	for e = range interp.size {
+		elts = append(elts, fmt.Sprint(e))
+	}
+
+	ss = append(ss, "D = {"+strings.Join(elts, ", ")+"}")
+
+	for _, arg = range sortedKeys(interp.consts, func(aA, aB fmla.Argument) (comp int) {
+		comp = byRune(rune(aA), rune(aB))
+
+		return
+	}) {
+		ss = append(ss, fmt.Sprintf("%s = %d", arg, interp.consts[arg]))
+	}
+
+	for _, fun = range sortedKeys(interp.funcs, func(fA, fB fmla.Function) (comp int) {
+		comp = byRune(rune(fA), rune(fB))
+
+		return
+	}) {
+		elts = []string{}
+
+		keys = sortedKeys(interp.funcs[fun], strings.Compare)
+
+		for _, key = range keys {
+			elts = append(elts, fmt.Sprintf("%s ↦ %d", tupleString(key), interp.funcs[fun][key]))
+		}
+
+		ss = append(ss, fmt.Sprintf("%s = {%s}", fun, strings.Join(elts, ", ")))
+	}
+
+	for _, pred = range sortedKeys(interp.exts, func(pA, pB fmla.Predicate) (comp int) {
+		comp = byRune(rune(pA), rune(pB))
+
+		return
+	}) {
+		// A 0-place predicate is just true or false.
+		if tv, ok = interp.exts[pred][tupleKey(nil)]; ok {
+			ss = append(ss, fmt.Sprintf("%s = %s", pred, truthString(tv)))
+
+			continue
+		}
+
+		elts = []string{}
+
+		for _, key = range sortedKeys(interp.exts[pred], strings.Compare) {
+			if interp.exts[pred][key] {
+				elts = append(elts, tupleString(key))
+			}
+		}
+
+		ss = append(ss, fmt.Sprintf("%s = {%s}", pred, strings.Join(elts, ", ")))
+	}
+
+	s = strings.Join(ss, "\n")
+
+	return
+}
@@ -0,0 +1,82 @@
+package sem
+
+import (
+	"Deriver/fmla"
+	"testing"
+)
+
+func TestInterpretationEvaluate(t *testing.T) {
+	type testCase struct {
+		s   string
+		exp bool
+	}
+
+	var (
+		interp *Interpretation
+		f      fmla.Function
+		tcs    []testCase
+		tc     testCase
+		wff    *fmla.WffTree
+		tv     bool
+		err    error
+	)
+
+	// Over {0, 1, 2}, a and b denote 0 and c denotes 1; F holds of 0 and 1; R is <; f is successor mod 3.
+	interp = NewInterpretation(3)
+
+	f = fmla.NewFunction('f', 0, 1)
+
+	for _, err = range []error{
+		interp.Assign('a', 0),
+		interp.Assign('b', 0),
+		interp.Assign('c', 1),
+		interp.SetExtension('F', []uint{0}, []uint{1}),
+		interp.SetExtension('R', []uint{0, 1}, []uint{0, 2}, []uint{1, 2}),
+		interp.SetExtension('P', []uint{}),
+		interp.SetFunc(f, []uint{0}, 1),
+		interp.SetFunc(f, []uint{1}, 2),
+		interp.SetFunc(f, []uint{2}, 0),
+	} {
+		if err != nil {
+			t.Fatalf("\nFAILED: %v", err)
+		}
+	}
+
+	tcs = []testCase{
+		{"(Fa∧Fc)∧P", true},
+		{"a=b", true},
+		{"a=c", false},
+		{"∀x((x=a∨x=c)∨Rcx)", true},
+		{"∃x∀y¬Ryx", true},
+		{"∀x∃yRxy", false},
+		{"f(a)=c", true},
+		{"∀x¬f(f(f(x)))=x", false},
+		{"∀x(Fx→(Rxf(f(x))∨Rf(x)x))", false},
+		{"∀X(Xa→Xb)", true},
+		{"∀X(Xa→Xc)", false},
+		{"∃X∀x(Xx↔¬Fx)", true},
+		{"∃Y∀x∀y(Yxy↔Ryx)", true},
+		{"∀X(X∨¬X)", true},
+		{"Gc", false},
+	}
+
+	for _, tc = range tcs {
+		if wff, err = fmla.ParseWff(tc.s); err != nil {
+			t.Fatalf("\nFAILED: %v", err)
+		}
+
+		if tv, err = interp.Evaluate(wff); err != nil || tv != tc.exp {
+			t.Errorf("\nFAILED: Expected %q to be %t, got %t (%v).", tc.s, tc.exp, tv, err)
+		} else {
+			t.Logf("\nPASSED: %q is %t.", tc.s, tv)
+		}
+	}
+
+	if err = interp.Assign('d', 3); err == nil {
+		t.Errorf("\nFAILED: Expected an error for an element outside the domain.")
+	}
+
+	if err = interp.SetExtension(fmla.Equals, []uint{0, 1}); err == nil {
+		t.Errorf("\nFAILED: Expected an error for setting the extension of =.")
+	}
+}
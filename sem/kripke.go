@@ -0,0 +1,340 @@
+package sem
+
+import (
+	"Deriver/fmla"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+type World uint
+
+// A FrameProperty is a set of conditions on the accessibility relation.
+type FrameProperty uint
+
+const (
+	Serial     FrameProperty = 1 << iota // Every world accesses some world.
+	Reflexive                            // Every world accesses itself.
+	Transitive                           // Every world accesses whatever its successors access.
+	Symmetric                            // Every world is accessed by its successors.
+)
+
+var framePropertyToName map[FrameProperty]string = map[FrameProperty]string{
+	Serial:     "serial",
+	Reflexive:  "reflexive",
+	Transitive: "transitive",
+	Symmetric:  "symmetric",
+}
+
+// A KripkeModel is a finite set of worlds, numbered from 0, with an accessibility
+// relation and, at each world, the set of atomic formulae true there.
+type KripkeModel struct {
+	acc [][]bool          // acc[w][v] is whether w accesses v.
+	val []map[string]bool // val[w] holds the atomic formulae true at w, by their strings.
+}
+
+func (fp FrameProperty) String() (s string) {
+	var (
+		ss []string
+		p  FrameProperty
+	)
+
+	for _, p = range []FrameProperty{Serial, Reflexive, Transitive, Symmetric} {
+		if fp&p != 0 {
+			ss = append(ss, framePropertyToName[p])
+		}
+	}
+
+	s = strings.Join(ss, ", ")
+
+	return
+}
+
+func NewKripkeModel(n uint) (km *KripkeModel) {
+	km = &KripkeModel{}
+
+	for range n {
+		km.AddWorld()
+	}
+
+	return
+}
+
+func (km *KripkeModel) AddWorld() (w World) {
+	var (
+		row []bool
+		dex int
+	)
+
+	w = World(len(km.acc))
+
+	for dex = range km.acc {
+		km.acc[dex] = append(km.acc[dex], false)
+	}
+
+	row = make([]bool, len(km.acc)+1)
+
+	km.acc, km.val = append(km.acc, row), append(km.val, map[string]bool{})
+
+	return
+}
+
+func (km *KripkeModel) Worlds() (ws []World) {
+	var (
+		dex int
+	)
+
+	for dex = range km.acc {
+		ws = append(ws, World(dex))
+	}
+
+	return
+}
+
+func (km *KripkeModel) hasWorld(w World) (has bool) {
+	has = int(w) < len(km.acc)
+
+	return
+}
+
+func (km *KripkeModel) SetAccess(w, v World) {
+	if !km.hasWorld(w) || !km.hasWorld(v) {
+		panic("Invalid World")
+	}
+
+	km.acc[w][v] = true
+}
+
+func (km *KripkeModel) Accesses(w, v World) (does bool) {
+	does = km.hasWorld(w) && km.hasWorld(v) && km.acc[w][v]
+
+	return
+}
+
+func (km *KripkeModel) Successors(w World) (vs []World) {
+	var (
+		v World
+	)
+
+	for _, v = range km.Worlds() {
+		if km.Accesses(w, v) {
+			vs = append(vs, v)
+		}
+	}
+
+	return
+}
+
+// SetValue makes an atomic formula true or false at a world.
+// Identities of identical terms are true everywhere, and can't be set.
+func (km *KripkeModel) SetValue(w World, atom *fmla.WffTree, tv bool) (err error) {
+	switch {
+	case !km.hasWorld(w):
+		err = fmt.Errorf("sem: there is no world %d", w)
+	case fmla.GetWffKind(atom) != fmla.Atomic:
+		err = fmt.Errorf("sem: %s is not atomic", fmla.GetWffString(atom))
+	case isTopOrBot(atom) || isReflexiveIdentity(atom):
+		err = fmt.Errorf("sem: the value of %s is fixed", fmla.GetWffString(atom))
+	case tv:
+		km.val[w][fmla.GetWffString(atom)] = true
+	default:
+		delete(km.val[w], fmla.GetWffString(atom))
+	}
+
+	return
+}
+
+func isTopOrBot(atom *fmla.WffTree) (is bool) {
+	var (
+		pred fmla.Predicate
+	)
+
+	pred, _, _ = fmla.GetWffPredAndArgs(atom)
+
+	is = pred == fmla.Top || pred == fmla.Bot
+
+	return
+}
+
+func isReflexiveIdentity(atom *fmla.WffTree) (is bool) {
+	var (
+		pred fmla.Predicate
+		ts   []*fmla.Term
+	)
+
+	if pred, ts, _ = fmla.GetWffPredAndTerms(atom); pred == fmla.Equals {
+		is = fmla.IsIdenticalTerm(ts[0], ts[1])
+	}
+
+	return
+}
+
+// TrueAtoms returns the strings of the atomic formulae true at a world, sorted.
+func (km *KripkeModel) TrueAtoms(w World) (ss []string) {
+	var (
+		s string
+	)
+
+	for s = range km.val[w] {
+		ss = append(ss, s)
+	}
+
+	slices.Sort(ss)
+
+	return
+}
+
+// Evaluate returns the truth value of a quantifier-free formula at a world:
+// □A holds at w when A holds at every world w accesses, and ◇A when A holds at some.
+func (km *KripkeModel) Evaluate(wff *fmla.WffTree, w World) (tv bool, err error) {
+	var (
+		pred       fmla.Predicate
+		subL, subR *fmla.WffTree
+		tvL, tvR   bool
+		v          World
+	)
+
+	if !km.hasWorld(w) {
+		err = fmt.Errorf("sem: there is no world %d", w)
+
+		return
+	}
+
+	switch fmla.GetWffKind(wff) {
+	case fmla.Atomic:
+		switch pred, _, _ = fmla.GetWffPredAndArgs(wff); {
+		case pred == fmla.Top:
+			tv = true
+		case pred == fmla.Bot:
+			tv = false
+		case isReflexiveIdentity(wff):
+			tv = true
+		default:
+			tv = km.val[w][fmla.GetWffString(wff)]
+		}
+	case fmla.Unary:
+		subL, _ = fmla.GetWffSubformulae(wff)
+
+		switch fmla.GetWffMop(wff) {
+		case fmla.Neg:
+			if tvL, err = km.Evaluate(subL, w); err == nil {
+				tv = !tvL
+			}
+		case fmla.Box:
+			tv = true
+
+			for _, v = range km.Successors(w) {
+				if tvL, err = km.Evaluate(subL, v); err != nil || !tvL {
+					tv = false
+
+					break
+				}
+			}
+		case fmla.Diamond:
+			for _, v = range km.Successors(w) {
+				if tvL, err = km.Evaluate(subL, v); err != nil || tvL {
+					tv = err == nil
+
+					break
+				}
+			}
+		}
+	case fmla.Binary:
+		subL, subR = fmla.GetWffSubformulae(wff)
+
+		if tvL, err = km.Evaluate(subL, w); err != nil {
+			return
+		}
+
+		if tvR, err = km.Evaluate(subR, w); err != nil {
+			return
+		}
+
+		switch fmla.GetWffMop(wff) {
+		case fmla.Wedge:
+			tv = tvL && tvR
+		case fmla.Vee:
+			tv = tvL || tvR
+		case fmla.To:
+			tv = !tvL || tvR
+		case fmla.Iff:
+			tv = tvL == tvR
+		}
+	default:
+		err = fmt.Errorf("sem: %s is quantified", fmla.GetWffString(wff))
+	}
+
+	return
+}
+
+// Frame returns every property that the accessibility relation has.
+func (km *KripkeModel) Frame() (fp FrameProperty) {
+	var (
+		ws      []World
+		w, v, u World
+		ok      bool
+	)
+
+	ws = km.Worlds()
+
+	fp = Serial | Reflexive | Transitive | Symmetric
+
+	for _, w = range ws {
+		ok = false
+
+		for _, v = range ws {
+			ok = ok || km.acc[w][v]
+
+			if km.acc[w][v] && !km.acc[v][w] {
+				fp &^= Symmetric
+			}
+
+			for _, u = range ws {
+				if km.acc[w][v] && km.acc[v][u] && !km.acc[w][u] {
+					fp &^= Transitive
+				}
+			}
+		}
+
+		if !ok {
+			fp &^= Serial
+		}
+
+		if !km.acc[w][w] {
+			fp &^= Reflexive
+		}
+	}
+
+	return
+}
+
+// HasFrame reports whether the accessibility relation has every property in fp.
+func (km *KripkeModel) HasFrame(fp FrameProperty) (has bool) {
+	has = km.Frame()&fp == fp
+
+	return
+}
+
+func (km *KripkeModel) String() (s string) {
+	var (
+		ss  []string
+		w   World
+		v   World
+		acc []string
+	)
+
+	for _, w = range km.Worlds() {
+		acc = []string{}
+
+		for _, v = range km.Successors(w) {
+			acc = append(acc, fmt.Sprintf("w%d", v))
+		}
+
+		ss = append(ss, fmt.Sprintf("w%d: {%s} accesses {%s}", w,
+			strings.Join(km.TrueAtoms(w), ", "), strings.Join(acc, ", ")))
+	}
+
+	s = strings.Join(ss, "\n")
+
+	return
+}
@@ -0,0 +1,71 @@
+package sem
+
+import (
+	"Deriver/fmla"
+	"testing"
+)
+
+func TestKripkeModel(t *testing.T) {
+	type testCase struct {
+		s   string
+		w   World
+		exp bool
+	}
+
+	var (
+		km  *KripkeModel
+		p   *fmla.WffTree
+		tcs []testCase
+		tc  testCase
+		wff *fmla.WffTree
+		tv  bool
+		err error
+	)
+
+	// w0 sees w1 and w2; P holds only at w1.
+	km = NewKripkeModel(3)
+
+	km.SetAccess(0, 1)
+	km.SetAccess(0, 2)
+
+	p, _ = fmla.ParseWff("P")
+
+	if err = km.SetValue(1, p, true); err != nil {
+		t.Fatalf("\nFAILED: %v", err)
+	}
+
+	tcs = []testCase{
+		{"◇P", 0, true},
+		{"□P", 0, false},
+		{"□P", 1, true},
+		{"◇⊤", 1, false},
+		{"□P→P", 0, true},
+		{"□(P∨¬P)", 0, true},
+		{"◇P∧◇¬P", 0, true},
+		{"a=a", 2, true},
+	}
+
+	for _, tc = range tcs {
+		if wff, err = fmla.ParseWff(tc.s); err != nil {
+			t.Fatalf("\nFAILED: %v", err)
+		}
+
+		if tv, err = km.Evaluate(wff, tc.w); err != nil || tv != tc.exp {
+			t.Errorf("\nFAILED: Expected %q to be %t at w%d, got %t (%v).", tc.s, tc.exp, tc.w, tv, err)
+		} else {
+			t.Logf("\nPASSED: %q is %t at w%d.", tc.s, tv, tc.w)
+		}
+	}
+
+	if fp := km.Frame(); fp != Transitive {
+		t.Errorf("\nFAILED: Expected a frame that is only transitive, got %q.", fp)
+	}
+
+	km.SetAccess(1, 1)
+	km.SetAccess(2, 2)
+	km.SetAccess(0, 0)
+
+	if !km.HasFrame(Serial|Reflexive|Transitive) || km.HasFrame(Symmetric) {
+		t.Errorf("\nFAILED: Expected a reflexive, transitive frame, got %q.", km.Frame())
+	}
+}
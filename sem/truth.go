@@ -0,0 +1,413 @@
+package sem
+
+import (
+	"Deriver/fmla"
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+// A Valuation assigns truth values to 0-place predicate constants.
+type Valuation map[fmla.Predicate]bool
+
+type TruthStatus int
+
+const (
+	Contingency TruthStatus = iota + 1
+	Tautology
+	Contradiction
+)
+
+var truthStatusToName map[TruthStatus]string = map[TruthStatus]string{
+	Contingency:   "contingency",
+	Tautology:     "tautology",
+	Contradiction: "contradiction",
+}
+
+// MaxTableAtoms caps the atoms of a truth table, which has 2^n rows for n atoms.
+const MaxTableAtoms uint = 16
+
+type TruthTable struct {
+	Atoms  []fmla.Predicate // The 0-place predicate constants, in alphabetical order.
+	Cols   []*fmla.WffTree  // The compound subformulae, from the shortest up to the formula itself.
+	Rows   []*TruthRow      // The rows, starting with every atom true.
+	Status TruthStatus      // Whether the formula is a tautology, contradiction or contingency.
+}
+
+type TruthRow struct {
+	Val    Valuation // The valuation of the atoms.
+	Values []bool    // The value of each column under Val.
+}
+
+func (ts TruthStatus) String() (s string) {
+	s = truthStatusToName[ts]
+
+	return
+}
+
+// IsPropositional reports whether the formula is in the propositional fragment:
+// free of quantifiers and modal operators, with only ⊤, ⊥ and 0-place predicate constants as atoms.
+func IsPropositional(wff *fmla.WffTree) (is bool) {
+	var (
+		pred fmla.Predicate
+		args []fmla.Argument
+		subL *fmla.WffTree
+		subR *fmla.WffTree
+	)
+
+	switch fmla.GetWffKind(wff) {
+	case fmla.Atomic:
+		pred, args, _ = fmla.GetWffPredAndArgs(wff)
+
+		is = len(args) == 0 && (pred == fmla.Top || pred == fmla.Bot || fmla.IsPredConst(pred))
+	case fmla.Unary:
+		if is = fmla.GetWffMop(wff) == fmla.Neg; is {
+			subL, _ = fmla.GetWffSubformulae(wff)
+
+			is = IsPropositional(subL)
+		}
+	case fmla.Binary:
+		subL, subR = fmla.GetWffSubformulae(wff)
+
+		is = IsPropositional(subL) && IsPropositional(subR)
+	}
+
+	return
+}
+
+// Evaluate returns the truth value of a propositional formula under the valuation.
+func Evaluate(wff *fmla.WffTree, val Valuation) (tv bool, err error) {
+	var (
+		pred       fmla.Predicate
+		args       []fmla.Argument
+		subL, subR *fmla.WffTree
+		tvL, tvR   bool
+		ok         bool
+	)
+
+	switch fmla.GetWffKind(wff) {
+	case fmla.Atomic:
+		switch pred, args, _ = fmla.GetWffPredAndArgs(wff); {
+		case pred == fmla.Top:
+			tv = true
+		case pred == fmla.Bot:
+			tv = false
+		case len(args) != 0 || !fmla.IsPredConst(pred):
+			err = fmt.Errorf("sem: %s is not a 0-place predicate constant", fmla.GetWffString(wff))
+		default:
+			if tv, ok = val[pred]; !ok {
+				err = fmt.Errorf("sem: the valuation omits %s", pred)
+			}
+		}
+	case fmla.Unary:
+		if fmla.GetWffMop(wff) != fmla.Neg {
+			err = fmt.Errorf("sem: %s is not propositional", fmla.GetWffString(wff))
+
+			return
+		}
+
+		subL, _ = fmla.GetWffSubformulae(wff)
+
+		if tvL, err = Evaluate(subL, val); err == nil {
+			tv = !tvL
+		}
+	case fmla.Binary:
+		subL, subR = fmla.GetWffSubformulae(wff)
+
+		if tvL, err = Evaluate(subL, val); err != nil {
+			return
+		}
+
+		if tvR, err = Evaluate(subR, val); err != nil {
+			return
+		}
+
+		switch fmla.GetWffMop(wff) {
+		case fmla.Wedge:
+			tv = tvL && tvR
+		case fmla.Vee:
+			tv = tvL || tvR
+		case fmla.To:
+			tv = !tvL || tvR
+		case fmla.Iff:
+			tv = tvL == tvR
+		}
+	default:
+		err = fmt.Errorf("sem: %s is not propositional", fmla.GetWffString(wff))
+	}
+
+	return
+}
+
+// collectAtoms returns the distinct 0-place predicate constants of the formula, in alphabetical order.
+func collectAtoms(wffs ...*fmla.WffTree) (atoms []fmla.Predicate) {
+	var (
+		wff  *fmla.WffTree
+		pcs  []fmla.Predicate
+		pc   fmla.Predicate
+		seen map[fmla.Predicate]bool
+	)
+
+	seen = map[fmla.Predicate]bool{}
+
+	for _, wff = range wffs {
+		pcs, _ = fmla.GetConstants(wff)
+
+		for _, pc = range pcs {
+			if !seen[pc] {
+				seen[pc], atoms = true, append(atoms, pc)
+			}
+		}
+	}
+
+	slices.SortFunc(atoms, func(pcA, pcB fmla.Predicate) (comp int) {
+		comp = cmp.Compare(fmla.PredOrdinal(pcA), fmla.PredOrdinal(pcB))
+
+		return
+	})
+
+	return
+}
+
+// collectColumns returns the distinct compound subformulae, shortest first.
+func collectColumns(wff *fmla.WffTree) (cols []*fmla.WffTree) {
+	var (
+		sub *fmla.WffTree
+	)
+
+	for _, sub = range slices.Backward(fmla.AllSubformulae(wff)) {
+		if fmla.GetWffKind(sub) == fmla.Atomic {
+			continue
+		}
+
+		if !slices.ContainsFunc(cols, func(col *fmla.WffTree) (is bool) {
+			is = fmla.IsIdentical(col, sub)
+
+			return
+		}) {
+			cols = append(cols, sub)
+		}
+	}
+
+	slices.SortStableFunc(cols, func(colA, colB *fmla.WffTree) (comp int) {
+		comp = cmp.Compare(fmla.GetWffLength(colA), fmla.GetWffLength(colB))
+
+		return
+	})
+
+	// An atomic formula is its own, sole column.
+	if len(cols) == 0 {
+		cols = []*fmla.WffTree{wff}
+	}
+
+	return
+}
+
+// NewTruthTable evaluates a propositional formula under every valuation of its atoms.
+func NewTruthTable(wff *fmla.WffTree) (tt *TruthTable, err error) {
+	var (
+		lenA, dexA int
+		n, rows    uint
+		row        *TruthRow
+		col        *fmla.WffTree
+		tv         bool
+		nTrue      uint
+	)
+
+	if !IsPropositional(wff) {
+		err = fmt.Errorf("sem: %s is not propositional", fmla.GetWffString(wff))
+
+		return
+	}
+
+	tt = &TruthTable{
+		Atoms: collectAtoms(wff),
+		Cols:  collectColumns(wff),
+	}
+
+	if lenA = len(tt.Atoms); MaxTableAtoms < uint(lenA) {
+		err = fmt.Errorf("sem: %d atoms exceed the %d a truth table allows", lenA, MaxTableAtoms)
+
+		return
+	}
+
+	rows = 1 << lenA
+
+	for n = range rows {
+		row = &TruthRow{Val: Valuation{}}
+
+		// The first atom alternates slowest, and every atom starts true.
+		for dexA = range lenA {
+			row.Val[tt.Atoms[dexA]] = n&(1<<(lenA-1-dexA)) == 0
+		}
+
+		for _, col = range tt.Cols {
+			if tv, err = Evaluate(col, row.Val); err != nil {
+				return
+			}
+
+			row.Values = append(row.Values, tv)
+		}
+
+		if tv {
+			nTrue += 1
+		}
+
+		tt.Rows = append(tt.Rows, row)
+	}
+
+	switch nTrue {
+	case rows:
+		tt.Status = Tautology
+	case 0:
+		tt.Status = Contradiction
+	default:
+		tt.Status = Contingency
+	}
+
+	return
+}
+
+func truthString(tv bool) (s string) {
+	if s = "F"; tv {
+		s = "T"
+	}
+
+	return
+}
+
+func centerString(s string, width int) (sC string) {
+	var (
+		gap int
+	)
+
+	gap = width - utf8.RuneCountInString(s)
+
+	sC = strings.Repeat(" ", gap/2) + s + strings.Repeat(" ", gap-gap/2)
+
+	return
+}
+
+// cells returns the table's header and rows as strings, centered in columns of equal width.
+func (tt *TruthTable) cells() (hdr []string, rows [][]string, widths []int) {
+	var (
+		pc     fmla.Predicate
+		col    *fmla.WffTree
+		row    *TruthRow
+		tv     bool
+		cs     []string
+		dex, w int
+	)
+
+	for _, pc = range tt.Atoms {
+		hdr = append(hdr, pc.String())
+	}
+
+	for _, col = range tt.Cols {
+		hdr = append(hdr, fmla.GetWffString(col))
+	}
+
+	for _, row = range tt.Rows {
+		cs = []string{}
+
+		for _, pc = range tt.Atoms {
+			cs = append(cs, truthString(row.Val[pc]))
+		}
+
+		for _, tv = range row.Values {
+			cs = append(cs, truthString(tv))
+		}
+
+		rows = append(rows, cs)
+	}
+
+	for dex = range hdr {
+		w = max(1, utf8.RuneCountInString(hdr[dex]))
+
+		widths = append(widths, w)
+
+		hdr[dex] = centerString(hdr[dex], w)
+
+		for _, cs = range rows {
+			cs[dex] = centerString(cs[dex], w)
+		}
+	}
+
+	return
+}
+
+// String renders the table in plain text, with the atoms set off from the columns.
+func (tt *TruthTable) String() (s string) {
+	var (
+		hdr    []string
+		rows   [][]string
+		widths []int
+		lines  []string
+		cs     []string
+		lenA   int
+		w      int
+		ss     []string
+		join   func(cs []string) (line string)
+	)
+
+	hdr, rows, widths = tt.cells()
+
+	lenA = len(tt.Atoms)
+
+	join = func(cs []string) (line string) {
+		line = strings.TrimRight(strings.Join(cs[:lenA], " ")+" | "+strings.Join(cs[lenA:], " "), " ")
+
+		return
+	}
+
+	lines = append(lines, join(hdr))
+
+	for _, w = range widths {
+		ss = append(ss, strings.Repeat("-", w))
+	}
+
+	lines = append(lines, strings.ReplaceAll(join(ss), " | ", "-+-"))
+
+	for _, cs = range rows {
+		lines = append(lines, join(cs))
+	}
+
+	lines = append(lines, "", fmt.Sprintf("%s is a %s.", fmla.GetWffString(tt.Cols[len(tt.Cols)-1]), tt.Status))
+
+	s = strings.Join(lines, "\n")
+
+	return
+}
+
+// Markdown renders the table as a Markdown table.
+func (tt *TruthTable) Markdown() (s string) {
+	var (
+		hdr    []string
+		rows   [][]string
+		widths []int
+		lines  []string
+		cs     []string
+		w      int
+		ss     []string
+	)
+
+	hdr, rows, widths = tt.cells()
+
+	lines = append(lines, "| "+strings.Join(hdr, " | ")+" |")
+
+	for _, w = range widths {
+		ss = append(ss, ":"+strings.Repeat("-", max(1, w-2))+":")
+	}
+
+	lines = append(lines, "| "+strings.Join(ss, " | ")+" |")
+
+	for _, cs = range rows {
+		lines = append(lines, "| "+strings.Join(cs, " | ")+" |")
+	}
+
+	s = strings.Join(lines, "\n")
+
+	return
+}
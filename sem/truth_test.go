@@ -0,0 +1,54 @@
+package sem
+
+import (
+	"Deriver/fmla"
+	"testing"
+)
+
+func TestNewTruthTable(t *testing.T) {
+	type testCase struct {
+		s   string
+		exp TruthStatus
+	}
+
+	var (
+		tcs []testCase
+		tc  testCase
+		wff *fmla.WffTree
+		tt  *TruthTable
+		err error
+	)
+
+	tcs = []testCase{
+		{"P∨¬P", Tautology},
+		{"P∧¬P", Contradiction},
+		{"(P→Q)→P", Contingency},
+		{"((P→Q)→P)→P", Tautology},
+		{"(P₁↔Q)↔(Q↔P₁)", Tautology},
+		{"⊤∧¬⊥", Tautology},
+	}
+
+	for _, tc = range tcs {
+		if wff, err = fmla.ParseWff(tc.s); err != nil {
+			t.Fatalf("\nFAILED: %v", err)
+		}
+
+		if tt, err = NewTruthTable(wff); err != nil {
+			t.Errorf("\nFAILED: %v", err)
+		} else if tt.Status != tc.exp {
+			t.Errorf("\nFAILED: Expected %q to be a %s, not a %s.\n%s", tc.s, tc.exp, tt.Status, tt)
+		} else {
+			t.Logf("\nPASSED:\n%s\n\n%s", tt, tt.Markdown())
+		}
+	}
+
+	for _, tc.s = range []string{"Fa", "□P", "∀X(X)"} {
+		if wff, err = fmla.ParseWff(tc.s); err != nil {
+			continue
+		}
+
+		if _, err = NewTruthTable(wff); err == nil {
+			t.Errorf("\nFAILED: Expected %q to be outside the propositional fragment.", tc.s)
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package tptp
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+type tokenKind int
+
+const (
+	lowerWord  tokenKind = iota + 1 // Functors, roles and names, including single-quoted ones.
+	upperWord                       // Variables.
+	dollarWord                      // Defined symbols such as $true.
+	numberWord                      // Integers, used only as statement names.
+	punct                           // Punctuation and connectives.
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	off  int // The byte offset of the token in the input.
+}
+
+// Longer connectives come first, so that the lexer reads them greedily.
+// The type operators > and * appear only in the tff type declarations that Read skips.
+var puncts = []string{
+	"<=>", "<~>", "=>", "<=", "~|", "~&", "!=",
+	"(", ")", "[", "]", ",", ":", ".", "!", "?", "~", "&", "|", "=", ">", "*",
+}
+
+type ReadError struct {
+	Offset int    // The byte offset in the input at which reading failed.
+	Msg    string // What was wrong there.
+}
+
+func (rerr *ReadError) Error() (s string) {
+	s = fmt.Sprintf("tptp: %s at offset %d", rerr.Msg, rerr.Offset)
+
+	return
+}
+
+func isWordRune(r rune) (is bool) {
+	is = r == '_' || ('a' <= r && r <= 'z') || ('A' <= r && r <= 'Z') || ('0' <= r && r <= '9')
+
+	return
+}
+
+func scanWord(s string, dex int) (end int) {
+	for end = dex; end < len(s) && isWordRune(rune(s[end])); end += 1 {
+	}
+
+	return
+}
+
+// tokenize splits TPTP input into tokens, skipping whitespace and comments.
+func tokenize(s string) (toks []token, err error) {
+	var (
+		dex, end int
+		r        rune
+		p        string
+		ok       bool
+	)
+
+	for dex < len(s) {
+		r, _ = utf8.DecodeRuneInString(s[dex:])
+
+		switch {
+		case unicode.IsSpace(r):
+			dex += 1
+		case r == '%':
+			if end = strings.IndexByte(s[dex:], '\n'); end == -1 {
+				dex = len(s)
+			} else {
+				dex += end + 1
+			}
+		case strings.HasPrefix(s[dex:], "/*"):
+			if end = strings.Index(s[dex+2:], "*/"); end == -1 {
+				err = &ReadError{dex, "unterminated comment"}
+
+				return
+			}
+
+			dex += end + 4
+		case r == '\'':
+			if end = strings.IndexByte(s[dex+1:], '\''); end == -1 {
+				err = &ReadError{dex, "unterminated quoted name"}
+
+				return
+			}
+
+			toks = append(toks, token{lowerWord, s[dex+1 : dex+1+end], dex})
+
+			dex += end + 2
+		case 'a' <= r && r <= 'z', 'A' <= r && r <= 'Z', '0' <= r && r <= '9', r == '$':
+			end = scanWord(s, dex+1)
+
+			switch {
+			case r == '$':
+				toks = append(toks, token{dollarWord, s[dex:end], dex})
+			case 'a' <= r && r <= 'z':
+				toks = append(toks, token{lowerWord, s[dex:end], dex})
+			case 'A' <= r && r <= 'Z':
+				toks = append(toks, token{upperWord, s[dex:end], dex})
+			default:
+				toks = append(toks, token{numberWord, s[dex:end], dex})
+			}
+
+			dex = end
+		default:
+			ok = false
+
+			for _, p = range puncts {
+				if ok = strings.HasPrefix(s[dex:], p); ok {
+					toks = append(toks, token{punct, p, dex})
+
+					dex += len(p)
+
+					break
+				}
+			}
+
+			if !ok {
+				err = &ReadError{dex, fmt.Sprintf("unexpected %q", r)}
+
+				return
+			}
+		}
+	}
+
+	return
+}
@@ -0,0 +1,739 @@
+package tptp
+
+import (
+	"Deriver/fmla"
+	"fmt"
+	"io"
+	"regexp"
+	"slices"
+	"strconv"
+)
+
+// A Problem holds the premises and goal read from TPTP statements, ready for nd.Derive,
+// together with the Deriver symbols chosen for each TPTP name.
+type Problem struct {
+	Goal      *fmla.WffTree   // The conjecture, or ⊥ when there is none, as in refutation problems.
+	GoalName  string          // The name of the conjecture, if any.
+	Prems     []*fmla.WffTree // The axioms and other premises, in order.
+	PremNames []string        // The names of the premises, in order.
+
+	Preds  map[string]fmla.Predicate // The predicate constants by TPTP name.
+	Consts map[string]fmla.Argument  // The argument constants by TPTP name.
+	Funcs  map[string]fmla.Function  // The function symbols by TPTP name.
+	Vars   map[string]fmla.Argument  // The argument variables by TPTP name.
+}
+
+var premiseRoles = []string{
+	"axiom", "hypothesis", "definition", "assumption",
+	"lemma", "theorem", "corollary", "plain", "negated_conjecture",
+}
+
+var (
+	predNameRegex  = regexp.MustCompile(`^p_([A-T])([1-9][0-9]*)?$`)
+	constNameRegex = regexp.MustCompile(`^c_([a-t])([1-9][0-9]*)?$`)
+	funcNameRegex  = regexp.MustCompile(`^f_([a-t])([1-9][0-9]*)?_([1-7])$`)
+	varNameRegex   = regexp.MustCompile(`^([U-Z])([1-9][0-9]*)?$`)
+)
+
+type reader struct {
+	toks []token
+	dex  int
+	end  int // The offset of the end of the input.
+
+	prob  *Problem
+	rsvd  map[rune]bool  // The symbols that names in Write's scheme claim.
+	bound map[string]int // The number of enclosing quantifiers binding each variable name.
+}
+
+func parseIndex(s string) (idx uint, ok bool) {
+	var (
+		n uint64
+		e error
+	)
+
+	if s == "" {
+		ok = true
+
+		return
+	}
+
+	if n, e = strconv.ParseUint(s, 10, 32); e == nil && n <= uint64(fmla.MaxIndex) {
+		idx, ok = uint(n), true
+	}
+
+	return
+}
+
+// schemeSymbol returns the symbol that a name in Write's scheme stands for, if it is one.
+func schemeSymbol(tok token) (r rune, ok bool) {
+	var (
+		ms    []string
+		idx   uint
+		arity uint64
+	)
+
+	switch {
+	case tok.kind == upperWord:
+		if ms = varNameRegex.FindStringSubmatch(tok.text); ms != nil {
+			if idx, ok = parseIndex(ms[2]); ok {
+				r = rune(fmla.NewIndexedArgument(rune(ms[1][0])-'A'+'a', idx))
+			}
+		}
+	case tok.kind != lowerWord:
+	case predNameRegex.MatchString(tok.text):
+		ms = predNameRegex.FindStringSubmatch(tok.text)
+
+		if idx, ok = parseIndex(ms[2]); ok {
+			r = rune(fmla.NewIndexedPredicate(rune(ms[1][0]), idx))
+		}
+	case constNameRegex.MatchString(tok.text):
+		ms = constNameRegex.FindStringSubmatch(tok.text)
+
+		if idx, ok = parseIndex(ms[2]); ok {
+			r = rune(fmla.NewIndexedArgument(rune(ms[1][0]), idx))
+		}
+	case funcNameRegex.MatchString(tok.text):
+		ms = funcNameRegex.FindStringSubmatch(tok.text)
+
+		arity, _ = strconv.ParseUint(ms[3], 10, 8)
+
+		if idx, ok = parseIndex(ms[2]); ok && idx <= fmla.MaxFuncIndex {
+			r = rune(fmla.NewFunction(rune(ms[1][0]), idx, uint(arity)))
+		} else {
+			ok = false
+		}
+	}
+
+	return
+}
+
+func (rdr *reader) errorf(format string, a ...any) (err error) {
+	var (
+		off int = rdr.end
+	)
+
+	if rdr.dex < len(rdr.toks) {
+		off = rdr.toks[rdr.dex].off
+	}
+
+	err = &ReadError{off, fmt.Sprintf(format, a...)}
+
+	return
+}
+
+func (rdr *reader) peek() (tok token) {
+	if rdr.dex < len(rdr.toks) {
+		tok = rdr.toks[rdr.dex]
+	}
+
+	return
+}
+
+func (rdr *reader) expect(text string) (err error) {
+	if rdr.peek().text != text {
+		err = rdr.errorf("expected %q", text)
+
+		return
+	}
+
+	rdr.dex += 1
+
+	return
+}
+
+func (rdr *reader) predicate(name string) (pred fmla.Predicate) {
+	var (
+		ok bool
+		r  rune
+		n  uint
+	)
+
+	if pred, ok = rdr.prob.Preds[name]; ok {
+		return
+	}
+
+	if r, ok = schemeSymbol(token{lowerWord, name, 0}); ok && fmla.IsPredConst(fmla.Predicate(r)) {
+		pred = fmla.Predicate(r)
+	} else {
+		for pred = fmla.NthPredConst(n); rdr.rsvd[rune(pred)]; pred = fmla.NthPredConst(n) {
+			n += 1
+		}
+	}
+
+	rdr.rsvd[rune(pred)], rdr.prob.Preds[name] = true, pred
+
+	return
+}
+
+func (rdr *reader) constant(name string) (arg fmla.Argument) {
+	var (
+		ok bool
+		r  rune
+		n  uint
+	)
+
+	if arg, ok = rdr.prob.Consts[name]; ok {
+		return
+	}
+
+	if r, ok = schemeSymbol(token{lowerWord, name, 0}); ok && fmla.IsArgConst(fmla.Argument(r)) {
+		arg = fmla.Argument(r)
+	} else {
+		for arg = fmla.NthArgConst(n); rdr.rsvd[rune(arg)]; arg = fmla.NthArgConst(n) {
+			n += 1
+		}
+	}
+
+	rdr.rsvd[rune(arg)], rdr.prob.Consts[name] = true, arg
+
+	return
+}
+
+func (rdr *reader) function(name string, arity uint) (fun fmla.Function, err error) {
+	var (
+		ok     bool
+		r      rune
+		n      uint
+		lenA   uint = uint(len(fmla.ArgConsts))
+		arityF uint
+	)
+
+	if arity == 0 || fmla.MaxArity < arity {
+		err = rdr.errorf("the arity of %s is not between 1 and %d", name, fmla.MaxArity)
+
+		return
+	}
+
+	if fun, ok = rdr.prob.Funcs[name]; !ok {
+		if r, ok = schemeSymbol(token{lowerWord, name, 0}); ok && fmla.IsFunction(fmla.Function(r)) {
+			fun = fmla.Function(r)
+		} else {
+			for fun = fmla.NewFunction('a', 0, arity); rdr.rsvd[rune(fun)]; {
+				if n += 1; fmla.MaxFuncIndex < n/lenA {
+					err = rdr.errorf("too many functions of arity %d", arity)
+
+					return
+				}
+
+				fun = fmla.NewFunction('a'+rune(n%lenA), n/lenA, arity)
+			}
+		}
+
+		rdr.rsvd[rune(fun)], rdr.prob.Funcs[name] = true, fun
+	}
+
+	if _, _, arityF = fmla.SplitFunction(fun); arityF != arity {
+		err = rdr.errorf("%s is used with arities %d and %d", name, arityF, arity)
+	}
+
+	return
+}
+
+func (rdr *reader) variable(name string) (arg fmla.Argument) {
+	var (
+		ok bool
+		r  rune
+		n  uint
+	)
+
+	if arg, ok = rdr.prob.Vars[name]; ok {
+		return
+	}
+
+	if r, ok = schemeSymbol(token{upperWord, name, 0}); ok {
+		arg = fmla.Argument(r)
+	} else {
+		for arg = fmla.NthArgVar(n); rdr.rsvd[rune(arg)]; arg = fmla.NthArgVar(n) {
+			n += 1
+		}
+	}
+
+	rdr.rsvd[rune(arg)], rdr.prob.Vars[name] = true, arg
+
+	return
+}
+
+// functorTerm builds the term that a functor and its arguments, if any, make.
+func (rdr *reader) functorTerm(name string, ts []*fmla.Term) (t *fmla.Term, err error) {
+	var (
+		fun fmla.Function
+	)
+
+	if len(ts) == 0 {
+		t = fmla.NewArgTerm(rdr.constant(name))
+
+		return
+	}
+
+	if fun, err = rdr.function(name, uint(len(ts))); err == nil {
+		t = fmla.NewFuncTerm(fun, ts...)
+	}
+
+	return
+}
+
+func (rdr *reader) parseTerm() (t *fmla.Term, err error) {
+	var (
+		tok token
+		ts  []*fmla.Term
+	)
+
+	switch tok = rdr.peek(); tok.kind {
+	case upperWord:
+		if rdr.bound[tok.text] == 0 {
+			err = rdr.errorf("the variable %s is unbound", tok.text)
+
+			return
+		}
+
+		rdr.dex += 1
+
+		t = fmla.NewArgTerm(rdr.variable(tok.text))
+	case lowerWord:
+		if rdr.dex += 1; rdr.peek().text == "(" {
+			if ts, err = rdr.parseArgs(); err != nil {
+				return
+			}
+		}
+
+		t, err = rdr.functorTerm(tok.text, ts)
+	default:
+		err = rdr.errorf("expected a term")
+	}
+
+	return
+}
+
+// parseArgs reads a parenthesized, comma-separated list of terms.
+func (rdr *reader) parseArgs() (ts []*fmla.Term, err error) {
+	var (
+		t *fmla.Term
+	)
+
+	if err = rdr.expect("("); err != nil {
+		return
+	}
+
+	for {
+		if t, err = rdr.parseTerm(); err != nil {
+			return
+		}
+
+		ts = append(ts, t)
+
+		if rdr.peek().text != "," {
+			break
+		}
+
+		rdr.dex += 1
+	}
+
+	err = rdr.expect(")")
+
+	return
+}
+
+func (rdr *reader) parseAtomic() (wff *fmla.WffTree, err error) {
+	var (
+		tok    token
+		ts     []*fmla.Term
+		tL, tR *fmla.Term
+		op     string
+	)
+
+	switch tok = rdr.peek(); {
+	case tok.text == "$true":
+		rdr.dex += 1
+
+		wff = fmla.NewAtomicWff(fmla.Top)
+
+		return
+	case tok.text == "$false":
+		rdr.dex += 1
+
+		wff = fmla.NewAtomicWff(fmla.Bot)
+
+		return
+	case tok.kind == lowerWord:
+		// A lower word followed by an identity sign is a term; otherwise it's a predicate.
+		if rdr.dex += 1; rdr.peek().text == "(" {
+			if ts, err = rdr.parseArgs(); err != nil {
+				return
+			}
+		}
+
+		if op = rdr.peek().text; op != "=" && op != "!=" {
+			wff = fmla.NewAtomicTermWff(rdr.predicate(tok.text), ts...)
+
+			return
+		}
+
+		if tL, err = rdr.functorTerm(tok.text, ts); err != nil {
+			return
+		}
+	case tok.kind == upperWord:
+		if tL, err = rdr.parseTerm(); err != nil {
+			return
+		}
+
+		if op = rdr.peek().text; op != "=" && op != "!=" {
+			err = rdr.errorf("expected %q or %q", "=", "!=")
+
+			return
+		}
+	default:
+		err = rdr.errorf("expected an atomic formula")
+
+		return
+	}
+
+	rdr.dex += 1
+
+	if tR, err = rdr.parseTerm(); err != nil {
+		return
+	}
+
+	if wff = fmla.NewAtomicTermWff(fmla.Equals, tL, tR); op == "!=" {
+		wff = fmla.NewCompositeWff(fmla.Neg, wff, nil, 0, 0)
+	}
+
+	return
+}
+
+// parseVarList reads the bracketed variables of a quantifier. TFF's typed variables
+// are accepted only at the type of individuals, $i.
+func (rdr *reader) parseVarList() (names []string, err error) {
+	var (
+		tok token
+	)
+
+	if err = rdr.expect("["); err != nil {
+		return
+	}
+
+	for {
+		if tok = rdr.peek(); tok.kind != upperWord {
+			err = rdr.errorf("expected a variable")
+
+			return
+		}
+
+		rdr.dex += 1
+
+		names = append(names, tok.text)
+
+		if rdr.peek().text == ":" {
+			if rdr.dex += 1; rdr.peek().text != "$i" {
+				err = rdr.errorf("expected the type %q", "$i")
+
+				return
+			}
+
+			rdr.dex += 1
+		}
+
+		if rdr.peek().text != "," {
+			break
+		}
+
+		rdr.dex += 1
+	}
+
+	err = rdr.expect("]")
+
+	return
+}
+
+func (rdr *reader) parseUnitary() (wff *fmla.WffTree, err error) {
+	var (
+		tok   token
+		names []string
+		name  string
+		dex   int
+		sym   fmla.Symbol
+	)
+
+	switch tok = rdr.peek(); tok.text {
+	case "!", "?":
+		if sym = fmla.ForAll; tok.text == "?" {
+			sym = fmla.Exists
+		}
+
+		rdr.dex += 1
+
+		if names, err = rdr.parseVarList(); err != nil {
+			return
+		}
+
+		if err = rdr.expect(":"); err != nil {
+			return
+		}
+
+		for _, name = range names {
+			rdr.bound[name] += 1
+		}
+
+		wff, err = rdr.parseUnitary()
+
+		for _, name = range names {
+			rdr.bound[name] -= 1
+		}
+
+		if err != nil {
+			return
+		}
+
+		// ![X,Y]: A is ![X]: ![Y]: A, so the last variable binds innermost.
+		for dex = len(names) - 1; 0 <= dex; dex -= 1 {
+			wff = fmla.NewCompositeWff(sym, wff, nil, 0, rdr.variable(names[dex]))
+		}
+	case "~":
+		rdr.dex += 1
+
+		if wff, err = rdr.parseUnitary(); err == nil {
+			wff = fmla.NewCompositeWff(fmla.Neg, wff, nil, 0, 0)
+		}
+	case "(":
+		rdr.dex += 1
+
+		if wff, err = rdr.parseFormula(); err == nil {
+			err = rdr.expect(")")
+		}
+	default:
+		wff, err = rdr.parseAtomic()
+	}
+
+	return
+}
+
+// parseFormula reads a unitary formula, a chain of conjuncts or disjuncts,
+// or two unitary formulae joined by one of the non-associative connectives.
+func (rdr *reader) parseFormula() (wff *fmla.WffTree, err error) {
+	var (
+		op   string
+		wffR *fmla.WffTree
+	)
+
+	if wff, err = rdr.parseUnitary(); err != nil {
+		return
+	}
+
+	switch op = rdr.peek().text; op {
+	case "&", "|":
+		for rdr.peek().text == op {
+			rdr.dex += 1
+
+			if wffR, err = rdr.parseUnitary(); err != nil {
+				return
+			}
+
+			if op == "&" {
+				wff = fmla.NewCompositeWff(fmla.Wedge, wff, wffR, 0, 0)
+			} else {
+				wff = fmla.NewCompositeWff(fmla.Vee, wff, wffR, 0, 0)
+			}
+		}
+	case "=>", "<=", "<=>", "<~>", "~|", "~&":
+		rdr.dex += 1
+
+		if wffR, err = rdr.parseUnitary(); err != nil {
+			return
+		}
+
+		switch op {
+		case "=>":
+			wff = fmla.NewCompositeWff(fmla.To, wff, wffR, 0, 0)
+		case "<=":
+			wff = fmla.NewCompositeWff(fmla.To, wffR, wff, 0, 0)
+		case "<=>":
+			wff = fmla.NewCompositeWff(fmla.Iff, wff, wffR, 0, 0)
+		case "<~>":
+			wff = fmla.NewCompositeWff(fmla.Neg, fmla.NewCompositeWff(fmla.Iff, wff, wffR, 0, 0), nil, 0, 0)
+		case "~|":
+			wff = fmla.NewCompositeWff(fmla.Neg, fmla.NewCompositeWff(fmla.Vee, wff, wffR, 0, 0), nil, 0, 0)
+		case "~&":
+			wff = fmla.NewCompositeWff(fmla.Neg, fmla.NewCompositeWff(fmla.Wedge, wff, wffR, 0, 0), nil, 0, 0)
+		}
+	}
+
+	return
+}
+
+// skipToClose skips tokens up to the parenthesis that closes the current statement.
+func (rdr *reader) skipToClose() (err error) {
+	var (
+		depth int
+		tok   token
+	)
+
+	for tok = rdr.peek(); depth != 0 || tok.text != ")"; tok = rdr.peek() {
+		switch tok.text {
+		case "(", "[":
+			depth += 1
+		case ")", "]":
+			depth -= 1
+		case "":
+			err = rdr.errorf("unexpected end of input")
+
+			return
+		}
+
+		rdr.dex += 1
+	}
+
+	return
+}
+
+func (rdr *reader) parseStatement() (err error) {
+	var (
+		lang, name, role token
+		wff              *fmla.WffTree
+	)
+
+	switch lang = rdr.peek(); lang.text {
+	case "fof", "tff":
+		rdr.dex += 1
+	case "include", "cnf", "thf":
+		err = rdr.errorf("%s statements are unsupported", lang.text)
+
+		return
+	default:
+		err = rdr.errorf("expected a fof or tff statement")
+
+		return
+	}
+
+	if err = rdr.expect("("); err != nil {
+		return
+	}
+
+	if name = rdr.peek(); name.kind != lowerWord && name.kind != numberWord {
+		err = rdr.errorf("expected a statement name")
+
+		return
+	}
+
+	rdr.dex += 1
+
+	if err = rdr.expect(","); err != nil {
+		return
+	}
+
+	if role = rdr.peek(); role.kind != lowerWord {
+		err = rdr.errorf("expected a role")
+
+		return
+	}
+
+	rdr.dex += 1
+
+	if err = rdr.expect(","); err != nil {
+		return
+	}
+
+	switch {
+	case role.text == "type" && lang.text == "tff":
+		// Every symbol is untyped, so type declarations add nothing.
+		if err = rdr.skipToClose(); err != nil {
+			return
+		}
+	case role.text == "conjecture":
+		if rdr.prob.GoalName != "" {
+			err = rdr.errorf("a second conjecture, %s, follows %s", name.text, rdr.prob.GoalName)
+
+			return
+		}
+
+		if wff, err = rdr.parseFormula(); err != nil {
+			return
+		}
+
+		rdr.prob.Goal, rdr.prob.GoalName = wff, name.text
+	case slices.Contains(premiseRoles, role.text):
+		if wff, err = rdr.parseFormula(); err != nil {
+			return
+		}
+
+		rdr.prob.Prems, rdr.prob.PremNames = append(rdr.prob.Prems, wff), append(rdr.prob.PremNames, name.text)
+	default:
+		rdr.dex -= 2
+
+		err = rdr.errorf("the role %s is unsupported", role.text)
+
+		return
+	}
+
+	// Skip any annotations.
+	if rdr.peek().text == "," {
+		if err = rdr.skipToClose(); err != nil {
+			return
+		}
+	}
+
+	if err = rdr.expect(")"); err == nil {
+		err = rdr.expect(".")
+	}
+
+	return
+}
+
+// ReadString reads fof statements, and tff statements over untyped individuals,
+// into a Problem. Names in the scheme that Write uses keep their Deriver symbols,
+// and other names take the first symbols left free.
+func ReadString(s string) (prob *Problem, err error) {
+	var (
+		rdr *reader
+		tok token
+		r   rune
+		ok  bool
+	)
+
+	rdr = &reader{
+		end: len(s),
+
+		prob: &Problem{
+			Preds:  map[string]fmla.Predicate{},
+			Consts: map[string]fmla.Argument{},
+			Funcs:  map[string]fmla.Function{},
+			Vars:   map[string]fmla.Argument{},
+		},
+		rsvd:  map[rune]bool{},
+		bound: map[string]int{},
+	}
+
+	if rdr.toks, err = tokenize(s); err != nil {
+		return
+	}
+
+	for _, tok = range rdr.toks {
+		if r, ok = schemeSymbol(tok); ok {
+			rdr.rsvd[r] = true
+		}
+	}
+
+	for rdr.dex < len(rdr.toks) {
+		if err = rdr.parseStatement(); err != nil {
+			return
+		}
+	}
+
+	if rdr.prob.Goal == nil {
+		rdr.prob.Goal = fmla.NewAtomicWff(fmla.Bot)
+	}
+
+	prob = rdr.prob
+
+	return
+}
+
+func Read(r io.Reader) (prob *Problem, err error) {
+	var (
+		bs []byte
+	)
+
+	if bs, err = io.ReadAll(r); err == nil {
+		prob, err = ReadString(string(bs))
+	}
+
+	return
+}
@@ -0,0 +1,96 @@
+package tptp
+
+import (
+	"Deriver/fmla"
+	"strings"
+	"testing"
+)
+
+func TestReadString(t *testing.T) {
+	var (
+		s    string
+		prob *Problem
+		err  error
+		exps []string
+		dex  int
+	)
+
+	s = `% Socrates, with identity.
+fof(humans_mortal, axiom, ![X]: (human(X) => mortal(X))).
+fof(socrates, axiom, human(socrates) & socrates = father(plato)).
+tff(ind_type, type, human: $i > $o).
+fof(goal, conjecture, ? [Y:$i, Z] : (mortal(Y) & Y != Z)).`
+
+	if prob, err = ReadString(s); err != nil {
+		t.Fatalf("\nFAILED: %v", err)
+	}
+
+	exps = []string{"∀x(Ax→Bx)", "Aa∧a=a(b)"}
+
+	for dex = range exps {
+		if got := fmla.GetWffString(prob.Prems[dex]); got != exps[dex] {
+			t.Errorf("\nFAILED: Expected premise %q, got %q.", exps[dex], got)
+		}
+	}
+
+	if got := fmla.GetWffString(prob.Goal); got != "∃y∃z(By∧¬y=z)" || prob.GoalName != "goal" {
+		t.Errorf("\nFAILED: Expected goal %q, got %s %q.", "∃y∃z(By∧¬y=z)", prob.GoalName, got)
+	} else {
+		t.Logf("\nPASSED: Read %q.", s)
+	}
+
+	for _, s = range []string{
+		"fof(a, axiom, p(X)).",
+		"fof(a, axiom, p & q | r).",
+		"cnf(a, axiom, p).",
+		"fof(a, axiom, p",
+	} {
+		if _, err = ReadString(s); err == nil {
+			t.Errorf("\nFAILED: Expected an error reading %q.", s)
+		} else {
+			t.Logf("\nPASSED: %q gave %v.", s, err)
+		}
+	}
+}
+
+func TestWriteRoundTrip(t *testing.T) {
+	var (
+		ss   []string
+		s    string
+		wff  *fmla.WffTree
+		prob *Problem
+		sb   strings.Builder
+		err  error
+	)
+
+	ss = []string{
+		"∀x₂(F₁x₂→∃y(Rx₂f₃(y,a₁)∧¬y=b))",
+		"(P∨⊥)↔⊤",
+	}
+
+	for _, s = range ss {
+		if wff, err = fmla.ParseWff(s); err != nil {
+			t.Fatalf("\nFAILED: %v", err)
+		}
+
+		sb.Reset()
+
+		if err = WriteProblem(&sb, wff); err != nil {
+			t.Fatalf("\nFAILED: Failed to write %q: %v.", s, err)
+		}
+
+		if prob, err = ReadString(sb.String()); err != nil {
+			t.Errorf("\nFAILED: Failed to read %q: %v.", sb.String(), err)
+		} else if !fmla.IsIdentical(wff, prob.Goal) {
+			t.Errorf("\nFAILED: Expected %q, got %q.", s, fmla.GetWffString(prob.Goal))
+		} else {
+			t.Logf("\nPASSED: Round-tripped %q through %q.", s, sb.String())
+		}
+	}
+
+	if wff, err = fmla.ParseWff("□P"); err == nil {
+		if _, err = FormatWff(wff); err == nil {
+			t.Errorf("\nFAILED: Expected an error writing a modal formula.")
+		}
+	}
+}
@@ -0,0 +1,259 @@
+package tptp
+
+import (
+	"Deriver/fmla"
+	"fmt"
+	"io"
+	"strings"
+	"unicode"
+)
+
+var symbolToTPTP map[fmla.Symbol]string = map[fmla.Symbol]string{
+	fmla.Neg:    "~",
+	fmla.Wedge:  "&",
+	fmla.Vee:    "|",
+	fmla.To:     "=>",
+	fmla.Iff:    "<=>",
+	fmla.ForAll: "!",
+	fmla.Exists: "?",
+}
+
+func indexSuffix(idx uint) (s string) {
+	if 0 < idx {
+		s = fmt.Sprint(idx)
+	}
+
+	return
+}
+
+// Deriver's symbols become TPTP names by a fixed scheme, which Read recognizes in turn:
+// the predicate F₁ is p_F1, the constant a₁ is c_a1, the binary function f₁ is f_f1_2,
+// and the variable x₁ is X1.
+func PredicateName(pred fmla.Predicate) (s string) {
+	var (
+		letter rune
+		idx    uint
+	)
+
+	letter, idx = fmla.SplitPredicate(pred)
+
+	s = "p_" + string(letter) + indexSuffix(idx)
+
+	return
+}
+
+func ConstantName(arg fmla.Argument) (s string) {
+	var (
+		letter rune
+		idx    uint
+	)
+
+	letter, idx = fmla.SplitArgument(arg)
+
+	s = "c_" + string(letter) + indexSuffix(idx)
+
+	return
+}
+
+func FunctionName(fun fmla.Function) (s string) {
+	var (
+		letter     rune
+		idx, arity uint
+	)
+
+	letter, idx, arity = fmla.SplitFunction(fun)
+
+	s = fmt.Sprintf("f_%c%s_%d", letter, indexSuffix(idx), arity)
+
+	return
+}
+
+func VariableName(arg fmla.Argument) (s string) {
+	var (
+		letter rune
+		idx    uint
+	)
+
+	letter, idx = fmla.SplitArgument(arg)
+
+	s = string(unicode.ToUpper(letter)) + indexSuffix(idx)
+
+	return
+}
+
+func formatTerm(t *fmla.Term, bvs map[fmla.Argument]bool) (s string, err error) {
+	var (
+		arg  fmla.Argument
+		fun  fmla.Function
+		subs []*fmla.Term
+		sub  *fmla.Term
+		ss   []string
+		ok   bool
+	)
+
+	if arg, ok = fmla.GetTermArg(t); ok {
+		switch {
+		case bvs[arg]:
+			s = VariableName(arg)
+		case fmla.IsArgVar(arg):
+			err = fmt.Errorf("tptp: the variable %s is free", arg)
+		default:
+			s = ConstantName(arg)
+		}
+
+		return
+	}
+
+	fun, subs, _ = fmla.GetTermFunc(t)
+
+	for _, sub = range subs {
+		if s, err = formatTerm(sub, bvs); err != nil {
+			return
+		}
+
+		ss = append(ss, s)
+	}
+
+	s = FunctionName(fun) + "(" + strings.Join(ss, ",") + ")"
+
+	return
+}
+
+func formatAtomic(wff *fmla.WffTree, bvs map[fmla.Argument]bool) (s string, err error) {
+	var (
+		pred fmla.Predicate
+		ts   []*fmla.Term
+		t    *fmla.Term
+		ss   []string
+	)
+
+	pred, ts, _ = fmla.GetWffPredAndTerms(wff)
+
+	for _, t = range ts {
+		if s, err = formatTerm(t, bvs); err != nil {
+			return
+		}
+
+		ss = append(ss, s)
+	}
+
+	switch {
+	case pred == fmla.Top:
+		s = "$true"
+	case pred == fmla.Bot:
+		s = "$false"
+	case pred == fmla.Equals:
+		s = ss[0] + " = " + ss[1]
+	case fmla.IsPredVar(pred):
+		err = fmt.Errorf("tptp: the predicate variable %s is beyond first-order logic", pred)
+	case len(ss) == 0:
+		s = PredicateName(pred)
+	default:
+		s = PredicateName(pred) + "(" + strings.Join(ss, ",") + ")"
+	}
+
+	return
+}
+
+func formatWff(wff *fmla.WffTree, bvs map[fmla.Argument]bool) (s string, err error) {
+	var (
+		mop        fmla.Symbol
+		pv         fmla.Predicate
+		av         fmla.Argument
+		subL, subR *fmla.WffTree
+		sL, sR     string
+		bound      bool
+	)
+
+	if fmla.GetWffKind(wff) == fmla.Atomic {
+		s, err = formatAtomic(wff, bvs)
+
+		return
+	}
+
+	mop = fmla.GetWffMop(wff)
+
+	subL, subR = fmla.GetWffSubformulae(wff)
+
+	switch fmla.GetWffKind(wff) {
+	case fmla.Unary:
+		if mop != fmla.Neg {
+			err = fmt.Errorf("tptp: the modal operator %c is beyond first-order logic", mop)
+
+			return
+		}
+
+		if sL, err = formatWff(subL, bvs); err == nil {
+			s = "~ " + sL
+		}
+	case fmla.Binary:
+		if sL, err = formatWff(subL, bvs); err != nil {
+			return
+		}
+
+		if sR, err = formatWff(subR, bvs); err != nil {
+			return
+		}
+
+		s = "(" + sL + " " + symbolToTPTP[mop] + " " + sR + ")"
+	case fmla.Quantified:
+		if _, pv, av = fmla.GetWffMopAndVars(wff); pv != 0 {
+			err = fmt.Errorf("tptp: quantifying over the predicate variable %s is beyond first-order logic", pv)
+
+			return
+		}
+
+		// Restore any outer binding of the same variable once its scope ends.
+		bound = bvs[av]
+
+		bvs[av] = true
+
+		sL, err = formatWff(subL, bvs)
+
+		bvs[av] = bound
+
+		if err == nil {
+			s = symbolToTPTP[mop] + " [" + VariableName(av) + "] : " + sL
+		}
+	}
+
+	return
+}
+
+// FormatWff writes a closed, first-order, non-modal formula in TPTP's FOF syntax.
+func FormatWff(wff *fmla.WffTree) (s string, err error) {
+	s, err = formatWff(wff, map[fmla.Argument]bool{})
+
+	return
+}
+
+// WriteWff writes the formula as a single fof statement with the given name and role.
+func WriteWff(w io.Writer, name, role string, wff *fmla.WffTree) (err error) {
+	var (
+		s string
+	)
+
+	if s, err = FormatWff(wff); err == nil {
+		_, err = fmt.Fprintf(w, "fof(%s, %s,\n    %s).\n", name, role, s)
+	}
+
+	return
+}
+
+// WriteProblem writes the premises as axioms, named ax1, ax2, and so on, and the goal as a conjecture.
+func WriteProblem(w io.Writer, goal *fmla.WffTree, prems ...*fmla.WffTree) (err error) {
+	var (
+		dex  int
+		prem *fmla.WffTree
+	)
+
+	for dex, prem = range prems {
+		if err = WriteWff(w, fmt.Sprintf("ax%d", dex+1), "axiom", prem); err != nil {
+			return
+		}
+	}
+
+	err = WriteWff(w, "goal", "conjecture", goal)
+
+	return
+}